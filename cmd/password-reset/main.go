@@ -0,0 +1,15 @@
+package main
+
+import (
+	"flag"
+	"tender-service/internal/app"
+)
+
+const configPath = "config/config.yaml"
+
+func main() {
+	username := flag.String("username", "", "employee to issue a password reset token for")
+	flag.Parse()
+
+	app.IssuePasswordReset(configPath, *username)
+}
@@ -4,14 +4,24 @@ import (
 	"fmt"
 	"github.com/ilyakaznacheev/cleanenv"
 	"path"
+	"time"
 )
 
 type (
 	Config struct {
-		App  `yaml:"app"`
-		HTTP `yaml:"http"`
-		Log  `yaml:"log"`
-		PG   `yaml:"postgres"`
+		App       `yaml:"app"`
+		HTTP      `yaml:"http"`
+		Log       `yaml:"log"`
+		PG        `yaml:"postgres"`
+		Auth      `yaml:"auth"`
+		Bid       `yaml:"bid"`
+		Scheduler `yaml:"scheduler"`
+		Storage   `yaml:"storage"`
+		Webhook   `yaml:"webhook"`
+		Outbox    `yaml:"outbox"`
+		Events    `yaml:"events"`
+		Metrics   `yaml:"metrics"`
+		Tracing   `yaml:"tracing"`
 	}
 
 	App struct {
@@ -20,7 +30,8 @@ type (
 	}
 
 	HTTP struct {
-		Address string `env-required:"true" yaml:"address" env:"SERVER_ADDRESS"`
+		Address    string        `env-required:"true" yaml:"address"     env:"SERVER_ADDRESS"`
+		DrainDelay time.Duration `env-default:"5s"    yaml:"drain_delay" env:"SERVER_DRAIN_DELAY"`
 	}
 
 	Log struct {
@@ -31,6 +42,68 @@ type (
 		MaxPoolSize int    `env-required:"true" yaml:"max_pool_size" env:"PG_MAX_POOL_SIZE"`
 		URL         string `env-required:"true" yaml:"url"           env:"POSTGRES_CONN"`
 	}
+
+	Auth struct {
+		SignKey       string        `env-required:"true" yaml:"sign_key"        env:"AUTH_SIGN_KEY"`
+		TokenTTL      time.Duration `env-required:"true" yaml:"token_ttl"       env:"AUTH_TOKEN_TTL"`
+		ResetTokenTTL time.Duration `env-default:"24h"   yaml:"reset_token_ttl" env:"AUTH_RESET_TOKEN_TTL"`
+		UsernameMode  bool          `env-default:"false" yaml:"username_mode"   env:"AUTH_USERNAME_MODE"`
+		SelfSignUp    bool          `env-default:"false" yaml:"self_sign_up"    env:"AUTH_SELF_SIGN_UP"`
+	}
+
+	Bid struct {
+		ApprovalQuorum int `env-default:"3" yaml:"approval_quorum" env:"BID_APPROVAL_QUORUM"`
+	}
+
+	Scheduler struct {
+		DeadlineInterval time.Duration `env-default:"1m" yaml:"deadline_interval" env:"SCHEDULER_DEADLINE_INTERVAL"`
+	}
+
+	Storage struct {
+		Driver       string   `env-default:"local"                yaml:"driver"        env:"STORAGE_DRIVER"`
+		LocalPath    string   `env-default:"./.data/attachments" yaml:"local_path"    env:"STORAGE_LOCAL_PATH"`
+		MaxFileSize  int64    `env-default:"10485760"            yaml:"max_file_size" env:"STORAGE_MAX_FILE_SIZE"`
+		AllowedTypes []string `yaml:"allowed_types" env:"STORAGE_ALLOWED_TYPES" env-separator:","`
+		S3Endpoint   string   `yaml:"s3_endpoint"   env:"STORAGE_S3_ENDPOINT"`
+		S3AccessKey  string   `yaml:"s3_access_key" env:"STORAGE_S3_ACCESS_KEY"`
+		S3SecretKey  string   `yaml:"s3_secret_key" env:"STORAGE_S3_SECRET_KEY"`
+		S3Bucket     string   `env-default:"tender-attachments" yaml:"s3_bucket"  env:"STORAGE_S3_BUCKET"`
+		S3Region     string   `env-default:"us-east-1"          yaml:"s3_region"  env:"STORAGE_S3_REGION"`
+		S3UseSSL     bool     `env-default:"false"              yaml:"s3_use_ssl" env:"STORAGE_S3_USE_SSL"`
+	}
+
+	Webhook struct {
+		DeliveryInterval time.Duration `env-default:"5s"    yaml:"delivery_interval" env:"WEBHOOK_DELIVERY_INTERVAL"`
+		Timeout          time.Duration `env-default:"10s"   yaml:"timeout"           env:"WEBHOOK_TIMEOUT"`
+		MaxAttempts      int           `env-default:"8"     yaml:"max_attempts"      env:"WEBHOOK_MAX_ATTEMPTS"`
+		BaseBackoff      time.Duration `env-default:"30s"   yaml:"base_backoff"      env:"WEBHOOK_BASE_BACKOFF"`
+		MaxBackoff       time.Duration `env-default:"6h"    yaml:"max_backoff"       env:"WEBHOOK_MAX_BACKOFF"`
+		BatchSize        int           `env-default:"50"    yaml:"batch_size"        env:"WEBHOOK_BATCH_SIZE"`
+		AllowInsecure    bool          `env-default:"false" yaml:"allow_insecure"    env:"WEBHOOK_ALLOW_INSECURE"`
+	}
+
+	Outbox struct {
+		RelayInterval time.Duration `env-default:"1s"  yaml:"relay_interval" env:"OUTBOX_RELAY_INTERVAL"`
+		BatchSize     int           `env-default:"100" yaml:"batch_size"     env:"OUTBOX_BATCH_SIZE"`
+		Sinks         []string      `env-default:"log,webhook,notify,subscribers" yaml:"sinks" env:"OUTBOX_SINKS" env-separator:","`
+	}
+
+	Events struct {
+		BufferSize     int           `env-default:"64"  yaml:"buffer_size"     env:"EVENTS_BUFFER_SIZE"`
+		ReconnectDelay time.Duration `env-default:"5s"  yaml:"reconnect_delay" env:"EVENTS_RECONNECT_DELAY"`
+		MembershipTTL  time.Duration `env-default:"30s" yaml:"membership_ttl"  env:"EVENTS_MEMBERSHIP_TTL"`
+	}
+
+	Metrics struct {
+		Namespace string `env-default:"tender_service" yaml:"namespace" env:"METRICS_NAMESPACE"`
+	}
+
+	Tracing struct {
+		Exporter     string  `env-default:"none"           yaml:"exporter"      env:"TRACING_EXPORTER"`
+		OTLPEndpoint string  `env-default:"localhost:4318" yaml:"otlp_endpoint" env:"TRACING_OTLP_ENDPOINT"`
+		OTLPInsecure bool    `env-default:"true"           yaml:"otlp_insecure" env:"TRACING_OTLP_INSECURE"`
+		SampleRatio  float64 `env-default:"1"              yaml:"sample_ratio"  env:"TRACING_SAMPLE_RATIO"`
+	}
 )
 
 func NewConfig(configPath string) (*Config, error) {
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"github.com/sirupsen/logrus"
 	"log"
@@ -9,12 +10,20 @@ import (
 	"syscall"
 	"tender-service/config"
 	"tender-service/internal/handler"
+	"tender-service/internal/metrics"
+	"tender-service/internal/outbox"
 	"tender-service/internal/repository"
 	"tender-service/internal/repository/postgres"
 	"tender-service/internal/server"
 	"tender-service/internal/service"
+	"tender-service/internal/storage"
+	"tender-service/internal/tracing"
+	"tender-service/internal/webhook"
+	"time"
 )
 
+const tracingShutdownTimeout = 5 * time.Second
+
 func Run(configPath string) {
 
 	cfg, err := config.NewConfig(configPath)
@@ -24,6 +33,19 @@ func Run(configPath string) {
 
 	SetLogrus(cfg.Log.Level)
 
+	logrus.Info("Initializing tracing...")
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:       cfg.Tracing.Exporter,
+		Endpoint:       cfg.Tracing.OTLPEndpoint,
+		Insecure:       cfg.Tracing.OTLPInsecure,
+		SampleRatio:    cfg.Tracing.SampleRatio,
+		ServiceName:    cfg.App.Name,
+		ServiceVersion: cfg.App.Version,
+	})
+	if err != nil {
+		log.Fatal(fmt.Errorf("app - Run - tracing.Setup: %w", err))
+	}
+
 	logrus.Info("Initializing postgres...")
 	db, err := postgres.NewPostgresDB(cfg.PG.URL, postgres.MaxPoolSize(cfg.PG.MaxPoolSize))
 	if err != nil {
@@ -31,18 +53,84 @@ func Run(configPath string) {
 	}
 	defer db.Close()
 
+	logrus.Info("Initializing metrics...")
+	appMetrics := metrics.New(cfg.Metrics.Namespace)
+	err = appMetrics.Register(metrics.NewPoolCollector(cfg.Metrics.Namespace, db.Pool))
+	if err != nil {
+		log.Fatal(fmt.Errorf("app - Run - appMetrics.Register: %w", err))
+	}
+
 	logrus.Info("Initializing repositories...")
 	repos := repository.NewRepository(db)
 
+	logrus.Info("Initializing blob storage...")
+	store, err := newBlobStore(cfg)
+	if err != nil {
+		log.Fatal(fmt.Errorf("app - Run - newBlobStore: %w", err))
+	}
+
+	subscribers := outbox.NewSubscribers()
+
 	logrus.Info("Initializing services...")
-	services := service.NewService(repos)
+	services := service.NewService(service.ServicesDependencies{
+		Repos:          repos,
+		Metrics:        appMetrics,
+		SignKey:        cfg.Auth.SignKey,
+		TokenTTL:       cfg.Auth.TokenTTL,
+		ResetTokenTTL:  cfg.Auth.ResetTokenTTL,
+		SelfSignUp:     cfg.Auth.SelfSignUp,
+		ApprovalQuorum: cfg.Bid.ApprovalQuorum,
+
+		BlobStore:              store,
+		MaxAttachmentSize:      cfg.Storage.MaxFileSize,
+		AllowedAttachmentTypes: cfg.Storage.AllowedTypes,
+
+		WebhookSender:      webhook.NewHTTPSender(cfg.App.Name+"/"+cfg.App.Version, cfg.Webhook.AllowInsecure),
+		WebhookTimeout:     cfg.Webhook.Timeout,
+		WebhookMaxAttempts: cfg.Webhook.MaxAttempts,
+		WebhookBaseBackoff: cfg.Webhook.BaseBackoff,
+		WebhookMaxBackoff:  cfg.Webhook.MaxBackoff,
+		WebhookBatchSize:   cfg.Webhook.BatchSize,
+
+		EventSinks:       cfg.Outbox.Sinks,
+		EventSubscribers: subscribers,
+		OutboxBatchSize:  cfg.Outbox.BatchSize,
+		EventBufferSize:  cfg.Events.BufferSize,
+		EventMembersTTL:  cfg.Events.MembershipTTL,
+
+		SchemaVersion: postgres.SchemaVersion,
+	})
+
+	logrus.Info("Starting scheduler...")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scheduler := NewScheduler(services.Tender, cfg.Scheduler.DeadlineInterval)
+	scheduler.Run(ctx)
+
+	logrus.Info("Starting webhook dispatcher...")
+	dispatcher := NewWebhookDispatcher(services.Webhook, cfg.Webhook.DeliveryInterval)
+	dispatcher.Run(ctx)
+
+	logrus.Info("Starting outbox relay...")
+	relay := NewOutboxRelay(services.Outbox, cfg.Outbox.RelayInterval)
+	relay.Run(ctx)
+
+	logrus.Info("Starting event listener...")
+	listener := NewEventListener(services.Event, cfg.Events.ReconnectDelay)
+	listener.Run(ctx)
 
 	logrus.Info("Initializing handlers and routes...")
-	handlers := handler.NewHandler(services)
+	if cfg.Auth.UsernameMode {
+		logrus.Warn("Username authentication mode is enabled, use it for local development only")
+	}
+	handlers := handler.NewHandler(services, appMetrics, cfg.Auth.UsernameMode)
 
 	logrus.Info("Starting http server...")
 	logrus.Debugf("Server address: %s", cfg.HTTP.Address)
-	srv := server.NewServer(handlers.InitRoutes(), server.Address(cfg.HTTP.Address))
+	srv := server.NewServer(handlers.InitRoutes(), server.Address(cfg.HTTP.Address),
+		server.DrainDelay(cfg.HTTP.DrainDelay))
+	srv.RegisterOnDrain(services.Health.Drain)
+	srv.RegisterOnShutdown(services.Event.Close)
 
 	logrus.Info("Configuring graceful shutdown...")
 	quit := make(chan os.Signal, 1)
@@ -59,4 +147,34 @@ func Run(configPath string) {
 	if err != nil {
 		logrus.Error(fmt.Errorf("app - Run - server.Shutdown: %w", err))
 	}
+
+	cancel()
+	<-scheduler.Done()
+	<-dispatcher.Done()
+	<-relay.Done()
+	<-listener.Done()
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer shutdownCancel()
+	err = shutdownTracing(shutdownCtx)
+	if err != nil {
+		logrus.Error(fmt.Errorf("app - Run - shutdownTracing: %w", err))
+	}
+}
+
+func newBlobStore(cfg *config.Config) (storage.BlobStore, error) {
+	switch cfg.Storage.Driver {
+	case "local":
+		return storage.NewLocalStore(cfg.Storage.LocalPath)
+	case "s3":
+		return storage.NewS3Store(context.Background(), storage.S3Config{
+			Endpoint:  cfg.Storage.S3Endpoint,
+			AccessKey: cfg.Storage.S3AccessKey,
+			SecretKey: cfg.Storage.S3SecretKey,
+			Bucket:    cfg.Storage.S3Bucket,
+			Region:    cfg.Storage.S3Region,
+			UseSSL:    cfg.Storage.S3UseSSL,
+		})
+	}
+	return nil, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
 }
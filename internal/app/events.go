@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"tender-service/internal/service"
+	"time"
+)
+
+type EventListener struct {
+	events         service.Event
+	reconnectDelay time.Duration
+	done           chan struct{}
+}
+
+func NewEventListener(events service.Event, reconnectDelay time.Duration) *EventListener {
+	return &EventListener{
+		events:         events,
+		reconnectDelay: reconnectDelay,
+		done:           make(chan struct{}),
+	}
+}
+
+func (l *EventListener) Run(ctx context.Context) {
+	go func() {
+		defer close(l.done)
+
+		for {
+			err := l.events.Listen(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			logrus.Errorf("app - EventListener - Listen: %v", err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(l.reconnectDelay):
+			}
+		}
+	}()
+}
+
+func (l *EventListener) Done() <-chan struct{} {
+	return l.done
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"tender-service/internal/service"
+	"time"
+)
+
+type OutboxRelay struct {
+	events   service.Outbox
+	interval time.Duration
+	done     chan struct{}
+}
+
+func NewOutboxRelay(events service.Outbox, interval time.Duration) *OutboxRelay {
+	return &OutboxRelay{
+		events:   events,
+		interval: interval,
+		done:     make(chan struct{}),
+	}
+}
+
+func (r *OutboxRelay) Run(ctx context.Context) {
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			r.relayEvents(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (r *OutboxRelay) Done() <-chan struct{} {
+	return r.done
+}
+
+func (r *OutboxRelay) relayEvents(ctx context.Context) {
+	for {
+		relayed, err := r.events.RelayEvents(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logrus.Errorf("app - OutboxRelay - relayEvents: %v", err)
+			return
+		}
+		if relayed > 0 {
+			logrus.Debugf("app - OutboxRelay - relayed %d events", relayed)
+		}
+		if relayed == 0 || ctx.Err() != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"tender-service/config"
+	"tender-service/internal/repository"
+	"tender-service/internal/repository/postgres"
+	"tender-service/internal/service"
+	"time"
+)
+
+// IssuePasswordReset prints a one-time password reset token for the employee. Operators use it to bootstrap
+// accounts that have no password yet and no owner who could issue a token over the API.
+func IssuePasswordReset(configPath string, username string) {
+	cfg, err := config.NewConfig(configPath)
+	if err != nil {
+		logrus.Fatalf("Config error: %s", err)
+	}
+
+	SetLogrus(cfg.Log.Level)
+
+	if username == "" {
+		logrus.Fatal("app - IssuePasswordReset: username is empty")
+	}
+
+	db, err := postgres.NewPostgresDB(cfg.PG.URL, postgres.MaxPoolSize(1))
+	if err != nil {
+		logrus.Fatal(fmt.Errorf("app - IssuePasswordReset - postgres.NewPostgresDB: %w", err))
+	}
+	defer db.Close()
+
+	employees := service.NewEmployeeService(repository.NewRepository(db), cfg.Auth.ResetTokenTTL, cfg.Auth.SelfSignUp)
+	reset, err := employees.IssuePasswordResetByUsername(context.Background(), username)
+	if err != nil {
+		logrus.Errorf("app - IssuePasswordReset - employees.IssuePasswordResetByUsername: %v", err)
+		return
+	}
+
+	fmt.Printf("token: %s\nexpires at: %s\n", reset.Token, reset.ExpiresAt.Format(time.RFC3339))
+}
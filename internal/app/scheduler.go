@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"tender-service/internal/service"
+	"time"
+)
+
+type Scheduler struct {
+	tenders  service.Tender
+	interval time.Duration
+	done     chan struct{}
+}
+
+func NewScheduler(tenders service.Tender, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		tenders:  tenders,
+		interval: interval,
+		done:     make(chan struct{}),
+	}
+}
+
+func (s *Scheduler) Run(ctx context.Context) {
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.closeExpiredTenders(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *Scheduler) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Scheduler) closeExpiredTenders(ctx context.Context) {
+	closed, err := s.tenders.CloseExpiredTenders(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		logrus.Errorf("app - Scheduler - closeExpiredTenders: %v", err)
+		return
+	}
+	if closed > 0 {
+		logrus.Infof("app - Scheduler - closed %d tenders with expired submission deadline", closed)
+	}
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"tender-service/internal/service"
+	"time"
+)
+
+type WebhookDispatcher struct {
+	webhooks service.Webhook
+	interval time.Duration
+	done     chan struct{}
+}
+
+func NewWebhookDispatcher(webhooks service.Webhook, interval time.Duration) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhooks: webhooks,
+		interval: interval,
+		done:     make(chan struct{}),
+	}
+}
+
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	go func() {
+		defer close(d.done)
+
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+
+		for {
+			d.deliverPending(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (d *WebhookDispatcher) Done() <-chan struct{} {
+	return d.done
+}
+
+func (d *WebhookDispatcher) deliverPending(ctx context.Context) {
+	for {
+		processed, err := d.webhooks.DeliverPending(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logrus.Errorf("app - WebhookDispatcher - deliverPending: %v", err)
+			return
+		}
+		if processed > 0 {
+			logrus.Debugf("app - WebhookDispatcher - processed %d webhook deliveries", processed)
+		}
+		if processed == 0 || ctx.Err() != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"io"
+	"time"
+)
+
+const (
+	AttachmentOwnerTender = "tender"
+	AttachmentOwnerBid    = "bid"
+)
+
+type AttachmentOwner struct {
+	Type string
+	Id   string
+}
+
+type Attachment struct {
+	Id               string    `db:"id"`
+	TenderId         *string   `db:"tender_id"`
+	BidId            *string   `db:"bid_id"`
+	FileName         string    `db:"file_name"`
+	ContentType      string    `db:"content_type"`
+	Size             int64     `db:"size"`
+	Checksum         string    `db:"checksum"`
+	StorageKey       string    `db:"storage_key" json:"-"`
+	UploadedBy       *string   `db:"uploaded_by"`
+	AddedInVersion   int       `db:"added_in_version"`
+	RemovedInVersion *int      `db:"removed_in_version"`
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+type AttachmentUpload struct {
+	FileName string
+	Size     int64
+	Content  io.Reader
+}
+
+type AttachmentContent struct {
+	Attachment
+	Content io.ReadCloser
+}
@@ -0,0 +1,6 @@
+package entity
+
+type SignInInput struct {
+	Username string `json:"username" binding:"required,max=50"`
+	Password string `json:"password" binding:"required"`
+}
@@ -1,28 +1,73 @@
 package entity
 
-import "time"
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
 
 type Bid struct {
-	Id          string    `db:"id"`
-	Name        string    `db:"name"`
-	Description string    `db:"description"`
-	Status      string    `db:"status"`
-	TenderId    string    `db:"tender_id"`
-	AuthorType  string    `db:"author_type"`
-	AuthorId    string    `db:"author_id"`
-	Version     int       `db:"version"`
-	CreatedAt   time.Time `db:"created_at"`
+	Id          string           `db:"id"`
+	Name        string           `db:"name"`
+	Description string           `db:"description"`
+	Status      string           `db:"status"`
+	TenderId    string           `db:"tender_id"`
+	AuthorType  string           `db:"author_type"`
+	AuthorId    string           `db:"author_id"`
+	Version     int              `db:"version"`
+	Price       *decimal.Decimal `db:"price"`
+	Currency    *string          `db:"currency"`
+	CreatedAt   time.Time        `db:"created_at"`
+}
+
+type BidDecision struct {
+	Id        string    `db:"id"`
+	BidId     string    `db:"bid_id"`
+	LotId     string    `db:"lot_id"`
+	UserId    string    `db:"user_id"`
+	Decision  string    `db:"decision"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type BidWithDecisions struct {
+	Bid
+	Decision  string
+	Decisions []BidDecision
+	Lots      []BidLot
+	Quorum    int
+}
+
+type TenderBids struct {
+	Sealed     bool
+	Count      int
+	Bids       []Bid
+	NextCursor string `json:"-"`
+	Total      int    `json:"-"`
 }
 
 type CreateBidInput struct {
-	Name        string `json:"name" binding:"required,max=100"`
-	Description string `json:"description" binding:"required,max=500"`
-	TenderId    string `json:"tenderId" binding:"required,max=100"`
-	AuthorType  string `json:"authorType"  binding:"required,oneof=Organization User"`
-	AuthorId    string `json:"authorId" binding:"required,max=100"`
+	Name        string           `json:"name" binding:"required,max=100"`
+	Description string           `json:"description" binding:"required,max=500"`
+	TenderId    string           `json:"tenderId" binding:"required,max=100"`
+	AuthorType  string           `json:"authorType"  binding:"required,oneof=Organization User"`
+	AuthorId    string           `json:"authorId" binding:"max=100"`
+	Price       *decimal.Decimal `json:"price"`
+	Currency    string           `json:"currency" binding:"required_with=Price,omitempty,iso4217"`
+	Lots        []BidLotInput    `json:"lots" binding:"omitempty,max=50,dive"`
+}
+
+type BidFilter struct {
+	LotId     string
+	MinPrice  *decimal.Decimal
+	MaxPrice  *decimal.Decimal
+	SortBy    string
+	SortOrder string
 }
 
 type EditBidInput struct {
-	Name        *string `json:"name"`
-	Description *string `json:"description"`
+	Name        *string          `json:"name"`
+	Description *string          `json:"description"`
+	Price       *decimal.Decimal `json:"price"`
+	Currency    *string          `json:"currency" binding:"omitempty,iso4217"`
+	Lots        []BidLotInput    `json:"lots" binding:"omitempty,max=50,dive"`
+	Version     *int             `json:"version"`
 }
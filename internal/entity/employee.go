@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+type Employee struct {
+	Id        string    `db:"id"`
+	Username  string    `db:"username"`
+	FirstName string    `db:"first_name"`
+	LastName  string    `db:"last_name"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type CreateEmployeeInput struct {
+	Username  string `json:"username" binding:"required,max=50"`
+	FirstName string `json:"firstName" binding:"max=50"`
+	LastName  string `json:"lastName" binding:"max=50"`
+	Password  string `json:"password" binding:"required,min=8,max=72"`
+}
+
+type EditEmployeeInput struct {
+	FirstName *string `json:"firstName"`
+	LastName  *string `json:"lastName"`
+	Password  *string `json:"password"`
+}
+
+type PasswordReset struct {
+	EmployeeId string
+	Token      string
+	ExpiresAt  time.Time
+}
+
+type ResetPasswordInput struct {
+	Token    string `json:"token" binding:"required,max=128"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
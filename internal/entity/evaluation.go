@@ -0,0 +1,68 @@
+package entity
+
+import "time"
+
+type Criterion struct {
+	Id          string    `db:"id"`
+	TenderId    string    `db:"tender_id"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	Weight      int       `db:"weight"`
+	MaxScore    int       `db:"max_score"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+type BidScore struct {
+	Id          string    `db:"id"`
+	BidId       string    `db:"bid_id"`
+	CriterionId string    `db:"criterion_id"`
+	UserId      string    `db:"user_id"`
+	Score       int       `db:"score"`
+	Comment     string    `db:"comment"`
+	BidVersion  int       `db:"bid_version"`
+	Version     int       `db:"version"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+type CriterionScore struct {
+	CriterionId  string
+	Name         string
+	Weight       int
+	MaxScore     int
+	AverageScore float64
+	Scorers      int
+}
+
+type BidEvaluation struct {
+	Rank     int
+	Bid      Bid
+	Total    float64
+	Criteria []CriterionScore
+}
+
+type TenderEvaluation struct {
+	TenderId string
+	Criteria []Criterion
+	Bids     []BidEvaluation
+}
+
+type CriterionInput struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=500"`
+	Weight      int    `json:"weight" binding:"required,min=1,max=100"`
+	MaxScore    int    `json:"maxScore" binding:"omitempty,min=1,max=100"`
+}
+
+type SetCriteriaInput struct {
+	Criteria []CriterionInput `json:"criteria" binding:"required,min=1,max=20,dive"`
+}
+
+type ScoreInput struct {
+	CriterionId string `json:"criterionId" binding:"required,max=100"`
+	Score       *int   `json:"score" binding:"required,min=0"`
+	Comment     string `json:"comment" binding:"max=500"`
+}
+
+type SubmitScoresInput struct {
+	Scores []ScoreInput `json:"scores" binding:"required,min=1,max=20,dive"`
+}
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+const (
+	EventTenderPublished = "tender.published"
+	EventTenderClosed    = "tender.closed"
+	EventBidCreated      = "bid.created"
+	EventBidEdited       = "bid.edited"
+	EventBidDecided      = "bid.decided"
+)
+
+type Event struct {
+	Sequence   int64     `json:"-"`
+	Id         string    `json:"id"`
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurredAt"`
+	Data       EventData `json:"data"`
+}
+
+type EventData struct {
+	TenderId string `json:"tenderId"`
+	BidId    string `json:"bidId,omitempty"`
+	Status   string `json:"status"`
+	Version  int    `json:"version"`
+	Decision string `json:"decision,omitempty"`
+}
+
+type EventFilter struct {
+	TenderIds []string
+	BidIds    []string
+}
@@ -0,0 +1,13 @@
+package entity
+
+const (
+	HealthStatusOk    = "ok"
+	HealthStatusFail  = "fail"
+	ReadinessReady    = "ready"
+	ReadinessNotReady = "not ready"
+)
+
+type Readiness struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
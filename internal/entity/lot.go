@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+type Lot struct {
+	Id           string           `db:"id"`
+	TenderId     string           `db:"tender_id"`
+	Number       int              `db:"number"`
+	Name         string           `db:"name"`
+	Description  string           `db:"description"`
+	ServiceType  string           `db:"service_type"`
+	Budget       *decimal.Decimal `db:"budget"`
+	Status       string           `db:"status"`
+	AwardedBidId *string          `db:"awarded_bid_id"`
+	CreatedAt    time.Time        `db:"created_at"`
+}
+
+type BidLot struct {
+	BidId    string           `db:"bid_id"`
+	LotId    string           `db:"lot_id"`
+	Price    *decimal.Decimal `db:"price"`
+	Decision *string          `db:"decision"`
+}
+
+type LotInput struct {
+	Name        string           `json:"name" binding:"required,max=100"`
+	Description string           `json:"description" binding:"required,max=500"`
+	ServiceType string           `json:"serviceType" binding:"required,oneof=Construction Delivery Manufacture"`
+	Budget      *decimal.Decimal `json:"budget"`
+}
+
+type AddLotsInput struct {
+	Lots []LotInput `json:"lots" binding:"required,min=1,max=50,dive"`
+}
+
+type BidLotInput struct {
+	LotId string           `json:"lotId" binding:"required,max=100"`
+	Price *decimal.Decimal `json:"price"`
+}
@@ -0,0 +1,59 @@
+package entity
+
+import "time"
+
+const (
+	RoleOwner    = "Owner"
+	RoleEditor   = "Editor"
+	RoleApprover = "Approver"
+	RoleViewer   = "Viewer"
+)
+
+const (
+	PermissionTenderCreate       = "tender:create"
+	PermissionTenderView         = "tender:view"
+	PermissionTenderEdit         = "tender:edit"
+	PermissionTenderRollback     = "tender:rollback"
+	PermissionTenderStatus       = "tender:status"
+	PermissionBidDecision        = "bid:decision"
+	PermissionBidScore           = "bid:score"
+	PermissionRoleManage         = "role:manage"
+	PermissionOrganizationManage = "organization:manage"
+)
+
+type Organization struct {
+	Id          string    `db:"id"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	Type        string    `db:"type"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+type CreateOrganizationInput struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=500"`
+	Type        string `json:"type" binding:"required,oneof=IE LLC JSC"`
+}
+
+type EditOrganizationInput struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Type        *string `json:"type"`
+}
+
+type OrganizationMember struct {
+	OrganizationId string `db:"organization_id"`
+	UserId         string `db:"user_id"`
+	Username       string `db:"username"`
+	Role           string `db:"role"`
+}
+
+type AddResponsibleInput struct {
+	UserId string `json:"userId" binding:"required,max=100"`
+	Role   string `json:"role" binding:"omitempty,oneof=Owner Editor Approver Viewer"`
+}
+
+type GrantRoleInput struct {
+	Role string `json:"role" binding:"required,oneof=Owner Editor Approver Viewer"`
+}
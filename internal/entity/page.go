@@ -0,0 +1,13 @@
+package entity
+
+type PageRequest struct {
+	Limit  int
+	Offset int
+	Cursor *string
+}
+
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Total      int    `json:"total"`
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+type Question struct {
+	Id         string     `db:"id"`
+	TenderId   string     `db:"tender_id"`
+	AuthorId   string     `db:"author_id"`
+	Text       string     `db:"text"`
+	Answer     *string    `db:"answer"`
+	Visibility *string    `db:"visibility"`
+	AnsweredBy *string    `db:"answered_by"`
+	AnsweredAt *time.Time `db:"answered_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+type AskQuestionInput struct {
+	Text string `json:"text" binding:"required,max=1000"`
+}
+
+type AnswerQuestionInput struct {
+	Answer     string `json:"answer" binding:"required,max=2000"`
+	Visibility string `json:"visibility" binding:"required,oneof=Public Private"`
+}
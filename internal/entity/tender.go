@@ -1,28 +1,59 @@
 package entity
 
-import "time"
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
 
 type Tender struct {
-	Id             string    `db:"id"`
-	Name           string    `db:"name"`
-	Description    string    `db:"description"`
-	ServiceType    string    `db:"service_type"`
-	Status         string    `db:"status"`
-	OrganizationId string    `db:"organization_id"`
-	Version        int       `db:"version"`
-	CreatedAt      time.Time `db:"created_at"`
+	Id                 string           `db:"id"`
+	Name               string           `db:"name"`
+	Description        string           `db:"description"`
+	ServiceType        string           `db:"service_type"`
+	Status             string           `db:"status"`
+	OrganizationId     string           `db:"organization_id"`
+	Version            int              `db:"version"`
+	SubmissionDeadline *time.Time       `db:"submission_deadline"`
+	QuestionDeadline   *time.Time       `db:"question_deadline"`
+	Sealed             bool             `db:"sealed"`
+	BidsOpenedAt       *time.Time       `db:"bids_opened_at"`
+	Budget             *decimal.Decimal `db:"budget"`
+	Currency           *string          `db:"currency"`
+	CreatedAt          time.Time        `db:"created_at"`
 }
 
 type CreateTenderInput struct {
-	Name            string `json:"name" binding:"required,max=100"`
-	Description     string `json:"description" binding:"required,max=500"`
-	ServiceType     string `json:"serviceType" binding:"required,oneof=Construction Delivery Manufacture"`
-	OrganizationId  string `json:"organizationId" binding:"required,max=100"`
-	CreatorUsername string `json:"creatorUsername" binding:"required"`
+	Name               string           `json:"name" binding:"required,max=100"`
+	Description        string           `json:"description" binding:"required,max=500"`
+	ServiceType        string           `json:"serviceType" binding:"required,oneof=Construction Delivery Manufacture"`
+	OrganizationId     string           `json:"organizationId" binding:"required,max=100"`
+	SubmissionDeadline *time.Time       `json:"submissionDeadline"`
+	QuestionDeadline   *time.Time       `json:"questionDeadline"`
+	Sealed             bool             `json:"sealed"`
+	Budget             *decimal.Decimal `json:"budget"`
+	Currency           string           `json:"currency" binding:"required_with=Budget,omitempty,iso4217"`
+	Lots               []LotInput       `json:"lots" binding:"omitempty,max=50,dive"`
+}
+
+type TenderFilter struct {
+	ServiceType    []string
+	Query          string
+	OrganizationId string
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	MinBudget      *decimal.Decimal
+	MaxBudget      *decimal.Decimal
+	SortBy         string
+	SortOrder      string
 }
 
 type EditTenderInput struct {
-	Name        *string `json:"name"`
-	Description *string `json:"description"`
-	ServiceType *string `json:"serviceType"`
+	Name               *string          `json:"name"`
+	Description        *string          `json:"description"`
+	ServiceType        *string          `json:"serviceType"`
+	SubmissionDeadline *time.Time       `json:"submissionDeadline"`
+	QuestionDeadline   *time.Time       `json:"questionDeadline"`
+	Budget             *decimal.Decimal `json:"budget"`
+	Currency           *string          `json:"currency" binding:"omitempty,iso4217"`
+	Version            *int             `json:"version"`
 }
@@ -0,0 +1,15 @@
+package entity
+
+type FieldChange struct {
+	Field   string
+	From    string
+	To      string
+	Changed bool
+}
+
+type VersionDiff struct {
+	Id          string
+	FromVersion int
+	ToVersion   int
+	Changes     []FieldChange
+}
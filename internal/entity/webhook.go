@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Webhook struct {
+	Id             string    `db:"id"`
+	OrganizationId string    `db:"organization_id"`
+	Url            string    `db:"url"`
+	Secret         string    `db:"secret" json:"-"`
+	Events         []string  `db:"events"`
+	CreatedBy      *string   `db:"created_by"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+type CreatedWebhook struct {
+	Webhook
+	Secret string
+}
+
+type CreateWebhookInput struct {
+	Url    string   `json:"url" binding:"required,url,max=2048"`
+	Events []string `json:"events" binding:"required,min=1,dive,oneof=tender.published tender.closed bid.created bid.edited bid.decided"`
+}
+
+type WebhookDelivery struct {
+	Id             string          `db:"id"`
+	WebhookId      string          `db:"webhook_id"`
+	EventId        string          `db:"event_id"`
+	Event          string          `db:"event"`
+	Payload        json.RawMessage `db:"payload"`
+	Status         string          `db:"status"`
+	Attempts       int             `db:"attempts"`
+	NextAttemptAt  time.Time       `db:"next_attempt_at"`
+	LastStatusCode *int            `db:"last_status_code"`
+	LastError      *string         `db:"last_error"`
+	DeliveredAt    *time.Time      `db:"delivered_at"`
+	CreatedAt      time.Time       `db:"created_at"`
+}
+
+type PendingDelivery struct {
+	WebhookDelivery
+	Url    string `db:"url"`
+	Secret string `db:"secret"`
+}
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"tender-service/internal/entity"
+)
+
+func (h *Handler) getAttachments(ctx *gin.Context) {
+	owner, err := attachmentOwner(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var version *int
+	if value, ok := ctx.GetQuery("version"); ok {
+		v, err := parseVersion(value)
+		if err != nil {
+			newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+		version = &v
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	attachments, err := h.services.Attachment.GetAttachments(ctx.Request.Context(), owner, userId, version)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attachments)
+}
+
+func (h *Handler) uploadAttachment(ctx *gin.Context) {
+	owner, err := attachmentOwner(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	version, err := expectedVersion(ctx, nil)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, fmt.Sprintf("file is required: %v", err))
+		return
+	}
+	if err := attachmentFileNameValidate(header.Filename); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.services.Attachment.UploadAttachment(ctx.Request.Context(), owner, userId, version, entity.AttachmentUpload{
+		FileName: header.Filename,
+		Size:     header.Size,
+		Content:  file,
+	})
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	setVersionTag(ctx, attachment.AddedInVersion)
+	ctx.JSON(http.StatusOK, attachment)
+}
+
+func (h *Handler) downloadAttachment(ctx *gin.Context) {
+	owner, err := attachmentOwner(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attachmentId := ctx.Param("attachmentId")
+	if err := attachmentIdValidate(attachmentId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	attachment, err := h.services.Attachment.DownloadAttachment(ctx.Request.Context(), owner, attachmentId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+	defer attachment.Content.Close()
+
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	ctx.Header("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	ctx.Header("X-Checksum-Sha256", attachment.Checksum)
+	ctx.Status(http.StatusOK)
+	ctx.Header("Content-Type", attachment.ContentType)
+	if _, err := io.Copy(ctx.Writer, attachment.Content); err != nil {
+		ctx.Error(err)
+	}
+}
+
+func (h *Handler) deleteAttachment(ctx *gin.Context) {
+	owner, err := attachmentOwner(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attachmentId := ctx.Param("attachmentId")
+	if err := attachmentIdValidate(attachmentId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	version, err := expectedVersion(ctx, nil)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.services.Attachment.DeleteAttachment(ctx.Request.Context(), owner, attachmentId, userId, version)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func attachmentOwner(ctx *gin.Context) (entity.AttachmentOwner, error) {
+	if bidId := ctx.Param("bidId"); bidId != "" {
+		return entity.AttachmentOwner{Type: entity.AttachmentOwnerBid, Id: bidId}, bidIdValidate(bidId)
+	}
+	tenderId := ctx.Param("tenderId")
+	return entity.AttachmentOwner{Type: entity.AttachmentOwnerTender, Id: tenderId}, tenderIdValidate(tenderId)
+}
+
+func attachmentIdValidate(attachmentId string) error {
+	if attachmentId == "" {
+		return fmt.Errorf("attachmentId is empty")
+	}
+	if len(attachmentId) > 100 {
+		return fmt.Errorf("attachmentId is too long, maxLength=100")
+	}
+	return nil
+}
+
+func attachmentFileNameValidate(fileName string) error {
+	if fileName == "" {
+		return fmt.Errorf("file name is empty")
+	}
+	if len(fileName) > 255 {
+		return fmt.Errorf("file name is too long, maxLength=255")
+	}
+	return nil
+}
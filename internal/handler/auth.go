@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"tender-service/internal/entity"
+)
+
+type signInResponse struct {
+	Token string `json:"token"`
+}
+
+func (h *Handler) signIn(ctx *gin.Context) {
+	var input entity.SignInInput
+
+	if err := ctx.Bind(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	token, err := h.services.Auth.GenerateToken(ctx.Request.Context(), input)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, signInResponse{Token: token})
+}
+
+func (h *Handler) resetPassword(ctx *gin.Context) {
+	var input entity.ResetPasswordInput
+
+	if err := ctx.Bind(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := h.services.Employee.ResetPassword(ctx.Request.Context(), input)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
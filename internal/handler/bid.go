@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 	"net/http"
 	"slices"
 	"strconv"
@@ -19,21 +20,24 @@ func (h *Handler) createBid(ctx *gin.Context) {
 		return
 	}
 
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+	if input.AuthorId != "" && input.AuthorId != userId {
+		newServiceErrorResponse(ctx, service.ErrNotEnoughPermissions)
+		return
+	}
+	input.AuthorId = userId
+
 	bid, err := h.services.Bid.CreateBid(ctx.Request.Context(), input)
 	if err != nil {
-		if errors.Is(err, service.ErrTenderNotFound) {
-			newErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
 		if errors.Is(err, service.ErrUserNotFound) {
-			newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-		if errors.Is(err, service.ErrNotEnoughPermissions) {
-			newErrorResponse(ctx, http.StatusForbidden, err.Error())
+			newServiceErrorResponseWithStatus(ctx, http.StatusUnauthorized, err)
 			return
 		}
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newServiceErrorResponse(ctx, err)
 		return
 	}
 
@@ -41,48 +45,25 @@ func (h *Handler) createBid(ctx *gin.Context) {
 }
 
 func (h *Handler) getUserBids(ctx *gin.Context) {
-	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "5"))
+	page, err := pageFromQuery(ctx)
 	if err != nil {
 		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
-	if err := limitValidate(limit); err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
-
-	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
-	if err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
-	if err := offsetValidate(offset); err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
 
-	username := ctx.Query("username")
-	if err := usernameValidate(username); err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
-	userId, err := h.services.Auth.GetUserId(ctx.Request.Context(), username)
+	userId, err := getUserId(ctx)
 	if err != nil {
-		if errors.Is(err, service.ErrUserNotFound) {
-			newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
 		return
 	}
 
-	bids, err := h.services.Bid.GetBidsByUserId(ctx.Request.Context(), limit, offset, userId)
+	bids, err := h.services.Bid.GetBidsByUserId(ctx.Request.Context(), page, userId)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newServiceErrorResponse(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, bids)
+	pageResponse(ctx, page, bids)
 }
 
 func (h *Handler) getBidsForTender(ctx *gin.Context) {
@@ -92,60 +73,46 @@ func (h *Handler) getBidsForTender(ctx *gin.Context) {
 		return
 	}
 
-	username := ctx.Query("username")
-	if err := usernameValidate(username); err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
-	userId, err := h.services.Auth.GetUserId(ctx.Request.Context(), username)
+	userId, err := getUserId(ctx)
 	if err != nil {
-		if errors.Is(err, service.ErrUserNotFound) {
-			newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
 		return
 	}
 
-	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "5"))
+	page, err := pageFromQuery(ctx)
 	if err != nil {
 		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
-	if err := limitValidate(limit); err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
 
-	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	filter, err := bidFilterFromQuery(ctx)
 	if err != nil {
 		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
-	if err := offsetValidate(offset); err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
 
-	bids, err := h.services.Bid.GetBidsForTender(ctx.Request.Context(), tenderId, userId, limit, offset)
+	bids, err := h.services.Bid.GetBidsForTender(ctx.Request.Context(), tenderId, userId, page, filter)
 	if err != nil {
-		if errors.Is(err, service.ErrTenderNotFound) {
-			newErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
 		if errors.Is(err, service.ErrUserNotFound) {
-			newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-		if errors.Is(err, service.ErrNotEnoughPermissions) {
-			newErrorResponse(ctx, http.StatusForbidden, err.Error())
+			newServiceErrorResponseWithStatus(ctx, http.StatusUnauthorized, err)
 			return
 		}
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newServiceErrorResponse(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, bids)
+	if page.Cursor != nil {
+		ctx.JSON(http.StatusOK, tenderBidsPage{
+			Page:   entity.Page[entity.Bid]{Items: bids.Bids, NextCursor: bids.NextCursor, Total: bids.Total},
+			Sealed: bids.Sealed,
+		})
+		return
+	}
+	if bids.Sealed {
+		ctx.Header("X-Bids-Sealed", "true")
+		ctx.Header("X-Bids-Count", strconv.Itoa(bids.Count))
+	}
+	ctx.JSON(http.StatusOK, bids.Bids)
 }
 
 func (h *Handler) getBidStatus(ctx *gin.Context) {
@@ -155,25 +122,15 @@ func (h *Handler) getBidStatus(ctx *gin.Context) {
 		return
 	}
 
-	username := ctx.Query("username")
-	if err := usernameValidate(username); err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
-	userId, err := h.services.Auth.GetUserId(ctx.Request.Context(), username)
+	userId, err := getUserId(ctx)
 	if err != nil {
-		if errors.Is(err, service.ErrUserNotFound) {
-			newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
 		return
 	}
 
 	status, err := h.services.Bid.GetBidStatus(ctx.Request.Context(), bidId, userId)
 	if err != nil {
-		//todo уточнить пришедшую ошибку и расписать коды ответа
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newServiceErrorResponse(ctx, err)
 		return
 	}
 
@@ -193,32 +150,15 @@ func (h *Handler) updateBidStatus(ctx *gin.Context) {
 		return
 	}
 
-	username := ctx.Query("username")
-	if err := usernameValidate(username); err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
-	userId, err := h.services.Auth.GetUserId(ctx.Request.Context(), username)
+	userId, err := getUserId(ctx)
 	if err != nil {
-		if errors.Is(err, service.ErrUserNotFound) {
-			newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
 		return
 	}
 
 	bid, err := h.services.Bid.UpdateBidStatus(ctx.Request.Context(), bidId, status, userId)
 	if err != nil {
-		if errors.Is(err, service.ErrBidNotFound) {
-			newErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		if errors.Is(err, service.ErrNotEnoughPermissions) {
-			newErrorResponse(ctx, http.StatusForbidden, err.Error())
-			return
-		}
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newServiceErrorResponse(ctx, err)
 		return
 	}
 
@@ -232,18 +172,9 @@ func (h *Handler) editBid(ctx *gin.Context) {
 		return
 	}
 
-	username := ctx.Query("username")
-	if err := usernameValidate(username); err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
-	userId, err := h.services.Auth.GetUserId(ctx.Request.Context(), username)
+	userId, err := getUserId(ctx)
 	if err != nil {
-		if errors.Is(err, service.ErrUserNotFound) {
-			newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
 		return
 	}
 
@@ -252,7 +183,7 @@ func (h *Handler) editBid(ctx *gin.Context) {
 		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
-	if input.Name == nil && input.Description == nil {
+	if input.Name == nil && input.Description == nil && input.Price == nil && input.Currency == nil {
 		newErrorResponse(ctx, http.StatusBadRequest, "no parameters for edit")
 		return
 	}
@@ -268,21 +199,19 @@ func (h *Handler) editBid(ctx *gin.Context) {
 			return
 		}
 	}
+	input.Version, err = expectedVersion(ctx, input.Version)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	bid, err := h.services.Bid.EditBid(ctx.Request.Context(), bidId, userId, input)
 	if err != nil {
-		if errors.Is(err, service.ErrBidNotFound) {
-			newErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		if errors.Is(err, service.ErrNotEnoughPermissions) {
-			newErrorResponse(ctx, http.StatusForbidden, err.Error())
-			return
-		}
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newServiceErrorResponse(ctx, err)
 		return
 	}
 
+	setVersionTag(ctx, bid.Version)
 	ctx.JSON(http.StatusOK, bid)
 }
 
@@ -299,35 +228,46 @@ func (h *Handler) submitBidDecision(ctx *gin.Context) {
 		return
 	}
 
-	username := ctx.Query("username")
-	if err := usernameValidate(username); err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+	lotId := ctx.Query("lotId")
+	if len(lotId) > 100 {
+		newErrorResponse(ctx, http.StatusBadRequest, "lotId is too long, maxLength=100")
 		return
 	}
-	userId, err := h.services.Auth.GetUserId(ctx.Request.Context(), username)
+
+	userId, err := getUserId(ctx)
 	if err != nil {
-		if errors.Is(err, service.ErrUserNotFound) {
-			newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
 		return
 	}
 
-	bid, err := h.services.Bid.SubmitBidDecision(ctx.Request.Context(), bidId, decision, userId)
+	bid, err := h.services.Bid.SubmitBidDecision(ctx.Request.Context(), bidId, lotId, decision, userId)
 	if err != nil {
-		if errors.Is(err, service.ErrBidNotFound) {
-			newErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		if errors.Is(err, service.ErrNotEnoughPermissions) {
-			newErrorResponse(ctx, http.StatusForbidden, err.Error())
-			return
-		}
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, bid)
+}
+
+func (h *Handler) getBidDecisions(ctx *gin.Context) {
+	bidId := ctx.Param("bidId")
+	if err := bidIdValidate(bidId); err != nil {
 		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	bid, err := h.services.Bid.GetBidDecisions(ctx.Request.Context(), bidId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
 	ctx.JSON(http.StatusOK, bid)
 }
 
@@ -348,34 +288,139 @@ func (h *Handler) rollbackBid(ctx *gin.Context) {
 		return
 	}
 
-	username := ctx.Query("username")
-	if err := usernameValidate(username); err != nil {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	bid, err := h.services.Bid.RollbackBid(ctx.Request.Context(), bidId, version, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, bid)
+}
+
+func (h *Handler) getBidVersions(ctx *gin.Context) {
+	bidId := ctx.Param("bidId")
+	if err := bidIdValidate(bidId); err != nil {
 		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
-	userId, err := h.services.Auth.GetUserId(ctx.Request.Context(), username)
+
+	userId, err := getUserId(ctx)
 	if err != nil {
 		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
 		return
 	}
 
-	bid, err := h.services.Bid.RollbackBid(ctx.Request.Context(), bidId, version, userId)
+	versions, err := h.services.Bid.GetBidVersions(ctx.Request.Context(), bidId, userId)
 	if err != nil {
-		if errors.Is(err, service.ErrBidNotFound) {
-			newErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		if errors.Is(err, service.ErrNotEnoughPermissions) {
-			newErrorResponse(ctx, http.StatusForbidden, err.Error())
-			return
-		}
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, versions)
+}
+
+func (h *Handler) getBidVersion(ctx *gin.Context) {
+	bidId := ctx.Param("bidId")
+	if err := bidIdValidate(bidId); err != nil {
 		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	version, err := parseVersion(ctx.Param("version"))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	bid, err := h.services.Bid.GetBidVersion(ctx.Request.Context(), bidId, version, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
 	ctx.JSON(http.StatusOK, bid)
 }
 
+func (h *Handler) diffBidVersions(ctx *gin.Context) {
+	bidId := ctx.Param("bidId")
+	if err := bidIdValidate(bidId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	from, err := parseVersion(ctx.Query("from"))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, "from: "+err.Error())
+		return
+	}
+	to, err := parseVersion(ctx.Query("to"))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, "to: "+err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	diff, err := h.services.Bid.DiffBidVersions(ctx.Request.Context(), bidId, from, to, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, diff)
+}
+
+func bidFilterFromQuery(ctx *gin.Context) (entity.BidFilter, error) {
+	filter := entity.BidFilter{
+		SortBy:    ctx.DefaultQuery("sort", "name"),
+		SortOrder: ctx.DefaultQuery("order", "asc"),
+	}
+	if !slices.Contains([]string{"name", "price", "created_at"}, filter.SortBy) {
+		return entity.BidFilter{}, fmt.Errorf("invalid sort, must be 'name'/'price'/'created_at'")
+	}
+	if !slices.Contains([]string{"asc", "desc"}, filter.SortOrder) {
+		return entity.BidFilter{}, fmt.Errorf("invalid order, must be 'asc'/'desc'")
+	}
+
+	filter.LotId = ctx.Query("lot_id")
+	if len(filter.LotId) > 100 {
+		return entity.BidFilter{}, fmt.Errorf("lot_id is too long, maxLength=100")
+	}
+
+	if value, ok := ctx.GetQuery("min_price"); ok {
+		minPrice, err := decimal.NewFromString(value)
+		if err != nil {
+			return entity.BidFilter{}, fmt.Errorf("invalid min_price, must be a decimal number")
+		}
+		filter.MinPrice = &minPrice
+	}
+	if value, ok := ctx.GetQuery("max_price"); ok {
+		maxPrice, err := decimal.NewFromString(value)
+		if err != nil {
+			return entity.BidFilter{}, fmt.Errorf("invalid max_price, must be a decimal number")
+		}
+		filter.MaxPrice = &maxPrice
+	}
+
+	return filter, nil
+}
+
 func bidIdValidate(bidId string) error {
 	if bidId == "" {
 		return fmt.Errorf("bidId is empty")
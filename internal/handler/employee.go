@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"tender-service/internal/entity"
+)
+
+func (h *Handler) createEmployee(ctx *gin.Context) {
+	var input entity.CreateEmployeeInput
+
+	if err := ctx.Bind(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var userId *string
+	if id, err := getUserId(ctx); err == nil {
+		userId = &id
+	}
+
+	employee, err := h.services.Employee.CreateEmployee(ctx.Request.Context(), input, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, employee)
+}
+
+func (h *Handler) getEmployees(ctx *gin.Context) {
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "5"))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := limitValidate(limit); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := offsetValidate(offset); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	employees, err := h.services.Employee.GetEmployees(ctx.Request.Context(), limit, offset)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, employees)
+}
+
+func (h *Handler) getEmployee(ctx *gin.Context) {
+	employeeId := ctx.Param("employeeId")
+	if err := memberIdValidate(employeeId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	employee, err := h.services.Employee.GetEmployeeById(ctx.Request.Context(), employeeId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, employee)
+}
+
+func (h *Handler) editEmployee(ctx *gin.Context) {
+	employeeId := ctx.Param("employeeId")
+	if err := memberIdValidate(employeeId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var input entity.EditEmployeeInput
+	if err = ctx.Bind(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if input.FirstName == nil && input.LastName == nil && input.Password == nil {
+		newErrorResponse(ctx, http.StatusBadRequest, "no parameters for edit")
+		return
+	}
+	if input.FirstName != nil {
+		if err := employeeNameValidate("firstName", *input.FirstName); err != nil {
+			newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if input.LastName != nil {
+		if err := employeeNameValidate("lastName", *input.LastName); err != nil {
+			newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if input.Password != nil {
+		if err := passwordValidate(*input.Password); err != nil {
+			newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	employee, err := h.services.Employee.EditEmployee(ctx.Request.Context(), employeeId, userId, input)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, employee)
+}
+
+func (h *Handler) deleteEmployee(ctx *gin.Context) {
+	employeeId := ctx.Param("employeeId")
+	if err := memberIdValidate(employeeId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	err = h.services.Employee.DeleteEmployee(ctx.Request.Context(), employeeId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *Handler) issuePasswordReset(ctx *gin.Context) {
+	employeeId := ctx.Param("employeeId")
+	if err := memberIdValidate(employeeId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	reset, err := h.services.Employee.IssuePasswordReset(ctx.Request.Context(), employeeId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, reset)
+}
+
+func employeeNameValidate(field string, name string) error {
+	if len(name) > 50 {
+		return fmt.Errorf("%s is too long, maxLength=50", field)
+	}
+	return nil
+}
+
+func passwordValidate(password string) error {
+	if len(password) < 8 {
+		return fmt.Errorf("password is too short, minLength=8")
+	}
+	if len(password) > 72 {
+		return fmt.Errorf("password is too long, maxLength=72")
+	}
+	return nil
+}
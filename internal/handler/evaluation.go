@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"tender-service/internal/entity"
+)
+
+func (h *Handler) getTenderCriteria(ctx *gin.Context) {
+	tenderId := ctx.Param("tenderId")
+	if err := tenderIdValidate(tenderId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	criteria, err := h.services.Evaluation.GetCriteria(ctx.Request.Context(), tenderId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, criteria)
+}
+
+func (h *Handler) setTenderCriteria(ctx *gin.Context) {
+	tenderId := ctx.Param("tenderId")
+	if err := tenderIdValidate(tenderId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var input entity.SetCriteriaInput
+	if err = ctx.Bind(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	criteria, err := h.services.Evaluation.SetCriteria(ctx.Request.Context(), tenderId, userId, input)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, criteria)
+}
+
+func (h *Handler) getTenderEvaluation(ctx *gin.Context) {
+	tenderId := ctx.Param("tenderId")
+	if err := tenderIdValidate(tenderId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	evaluation, err := h.services.Evaluation.GetTenderEvaluation(ctx.Request.Context(), tenderId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, evaluation)
+}
+
+func (h *Handler) submitBidScores(ctx *gin.Context) {
+	bidId := ctx.Param("bidId")
+	if err := bidIdValidate(bidId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var input entity.SubmitScoresInput
+	if err = ctx.Bind(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	scores, err := h.services.Evaluation.SubmitScores(ctx.Request.Context(), bidId, userId, input)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, scores)
+}
+
+func (h *Handler) getBidScores(ctx *gin.Context) {
+	bidId := ctx.Param("bidId")
+	if err := bidIdValidate(bidId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	scores, err := h.services.Evaluation.GetBidScores(ctx.Request.Context(), bidId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, scores)
+}
+
+func (h *Handler) getBidScoreHistory(ctx *gin.Context) {
+	bidId := ctx.Param("bidId")
+	if err := bidIdValidate(bidId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	scores, err := h.services.Evaluation.GetBidScoreHistory(ctx.Request.Context(), bidId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, scores)
+}
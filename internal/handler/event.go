@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"tender-service/internal/entity"
+	"time"
+)
+
+const (
+	eventsHeartbeatInterval = 15 * time.Second
+	maxEventFilterIds       = 50
+)
+
+func (h *Handler) streamEvents(ctx *gin.Context) {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	filter, err := eventFilterFromQuery(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	events, unsubscribe, err := h.services.Event.Subscribe(ctx.Request.Context(), userId, filter)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+	defer unsubscribe()
+
+	_ = http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{})
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventsHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(ctx.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				ctx.Error(err)
+				return
+			}
+			if _, err := fmt.Fprintf(ctx.Writer, "id: %s\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data); err != nil {
+				return
+			}
+		}
+		ctx.Writer.Flush()
+	}
+}
+
+func eventFilterFromQuery(ctx *gin.Context) (entity.EventFilter, error) {
+	filter := entity.EventFilter{
+		TenderIds: ctx.QueryArray("tender_id"),
+		BidIds:    ctx.QueryArray("bid_id"),
+	}
+	if len(filter.TenderIds)+len(filter.BidIds) > maxEventFilterIds {
+		return entity.EventFilter{}, fmt.Errorf("too many tender_id and bid_id parameters, max=%d", maxEventFilterIds)
+	}
+	for _, tenderId := range filter.TenderIds {
+		if err := tenderIdValidate(tenderId); err != nil {
+			return entity.EventFilter{}, err
+		}
+	}
+	for _, bidId := range filter.BidIds {
+		if err := bidIdValidate(bidId); err != nil {
+			return entity.EventFilter{}, err
+		}
+	}
+	return filter, nil
+}
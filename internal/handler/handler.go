@@ -3,38 +3,111 @@ package handler
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"tender-service/internal/metrics"
 	"tender-service/internal/service"
 )
 
 type Handler struct {
-	services *service.Service
+	services     *service.Service
+	metrics      *metrics.Metrics
+	usernameMode bool
 }
 
-func NewHandler(services *service.Service) *Handler {
-	return &Handler{services: services}
+func NewHandler(services *service.Service, metrics *metrics.Metrics, usernameMode bool) *Handler {
+	return &Handler{
+		services:     services,
+		metrics:      metrics,
+		usernameMode: usernameMode,
+	}
 }
 
 func (h *Handler) InitRoutes() *gin.Engine {
 	router := gin.New()
-	router.Use(gin.Logger())
+	router.Use(h.requestTracing)
+	router.Use(h.requestLogger)
 	router.Use(gin.Recovery())
+	router.Use(h.requestMetrics)
+
+	router.GET("/metrics", gin.WrapH(h.metrics.Handler()))
+	router.GET("/healthz", h.liveness)
+	router.GET("/readyz", h.readiness)
 
-	api := router.Group("/api")
+	api := router.Group("/api", h.organizationSelector)
 	{
 		api.GET("/ping", h.checkServer)
+		api.GET("/events/stream", h.userIdentity, h.streamEvents)
+
+		auth := api.Group("/auth")
+		{
+			auth.POST("/sign-in", h.signIn)
+			auth.POST("/reset-password", h.resetPassword)
+		}
 
 		tenders := api.Group("/tenders")
 		{
 			tenders.GET("/", h.getTenders)
-			tenders.POST("/new", h.createTender)
-			tenders.GET("/my", h.getUserTenders)
-			tenders.GET("/:tenderId/status", h.getTenderStatus)
-			tenders.PUT("/:tenderId/status", h.updateTenderStatus)
-			tenders.PATCH("/:tenderId/edit", h.editTender)
-			tenders.PUT("/:tenderId/rollback/:version", h.rollbackTender)
+			tenders.GET("/:tenderId/status", h.optionalUserIdentity, h.getTenderStatus)
+			tenders.GET("/:tenderId/lots", h.optionalUserIdentity, h.getTenderLots)
+			tenders.GET("/:tenderId/questions", h.optionalUserIdentity, h.getTenderQuestions)
+
+			authorized := tenders.Group("", h.userIdentity)
+			{
+				authorized.POST("/new", h.createTender)
+				authorized.GET("/my", h.getUserTenders)
+				authorized.PUT("/:tenderId/status", h.updateTenderStatus)
+				authorized.PATCH("/:tenderId/edit", h.editTender)
+				authorized.PUT("/:tenderId/rollback/:version", h.rollbackTender)
+				authorized.PUT("/:tenderId/open_bids", h.openTenderBids)
+				authorized.GET("/:tenderId/versions", h.getTenderVersions)
+				authorized.GET("/:tenderId/versions/:version", h.getTenderVersion)
+				authorized.GET("/:tenderId/diff", h.diffTenderVersions)
+				authorized.POST("/:tenderId/lots", h.addTenderLots)
+				authorized.PUT("/:tenderId/lots/:lotId/cancel", h.cancelTenderLot)
+				authorized.POST("/:tenderId/questions", h.askTenderQuestion)
+				authorized.PUT("/:tenderId/questions/:questionId/answer", h.answerTenderQuestion)
+				authorized.GET("/:tenderId/criteria", h.getTenderCriteria)
+				authorized.PUT("/:tenderId/criteria", h.setTenderCriteria)
+				authorized.GET("/:tenderId/evaluation", h.getTenderEvaluation)
+				authorized.GET("/:tenderId/attachments", h.getAttachments)
+				authorized.POST("/:tenderId/attachments", h.uploadAttachment)
+				authorized.GET("/:tenderId/attachments/:attachmentId", h.downloadAttachment)
+				authorized.DELETE("/:tenderId/attachments/:attachmentId", h.deleteAttachment)
+			}
+		}
+
+		employees := api.Group("/employees")
+		{
+			employees.POST("/new", h.optionalUserIdentity, h.createEmployee)
+
+			authorized := employees.Group("", h.userIdentity)
+			{
+				authorized.GET("/", h.getEmployees)
+				authorized.GET("/:employeeId", h.getEmployee)
+				authorized.PATCH("/:employeeId/edit", h.editEmployee)
+				authorized.DELETE("/:employeeId", h.deleteEmployee)
+				authorized.POST("/:employeeId/password_reset", h.issuePasswordReset)
+			}
+		}
+
+		organizations := api.Group("/organizations", h.userIdentity)
+		{
+			organizations.GET("/", h.getOrganizations)
+			organizations.POST("/new", h.createOrganization)
+			organizations.GET("/:organizationId", h.getOrganization)
+			organizations.PATCH("/:organizationId/edit", h.editOrganization)
+			organizations.DELETE("/:organizationId", h.deleteOrganization)
+			organizations.POST("/:organizationId/responsibles", h.addResponsible)
+			organizations.DELETE("/:organizationId/responsibles/:userId", h.removeResponsible)
+			organizations.GET("/:organizationId/roles", h.getOrganizationRoles)
+			organizations.PUT("/:organizationId/roles/:userId", h.grantRole)
+			organizations.DELETE("/:organizationId/roles/:userId", h.revokeRole)
+			organizations.GET("/:organizationId/webhooks", h.getWebhooks)
+			organizations.POST("/:organizationId/webhooks", h.createWebhook)
+			organizations.DELETE("/:organizationId/webhooks/:webhookId", h.deleteWebhook)
+			organizations.GET("/:organizationId/webhooks/:webhookId/deliveries", h.getWebhookDeliveries)
 		}
 
-		bids := api.Group("/bids")
+		bids := api.Group("/bids", h.userIdentity)
 		{
 			bids.POST("/new", h.createBid)
 			bids.GET("/my", h.getUserBids)
@@ -43,7 +116,18 @@ func (h *Handler) InitRoutes() *gin.Engine {
 			bids.PUT("/:bidId/status", h.updateBidStatus)
 			bids.PATCH("/:bidId/edit", h.editBid)
 			bids.PUT("/:bidId/submit_decision", h.submitBidDecision)
+			bids.GET("/:bidId/decisions", h.getBidDecisions)
 			bids.PUT("/:bidId/rollback/:version", h.rollbackBid)
+			bids.GET("/:bidId/versions", h.getBidVersions)
+			bids.GET("/:bidId/versions/:version", h.getBidVersion)
+			bids.GET("/:bidId/diff", h.diffBidVersions)
+			bids.PUT("/:bidId/scores", h.submitBidScores)
+			bids.GET("/:bidId/scores", h.getBidScores)
+			bids.GET("/:bidId/scores/history", h.getBidScoreHistory)
+			bids.GET("/:bidId/attachments", h.getAttachments)
+			bids.POST("/:bidId/attachments", h.uploadAttachment)
+			bids.GET("/:bidId/attachments/:attachmentId", h.downloadAttachment)
+			bids.DELETE("/:bidId/attachments/:attachmentId", h.deleteAttachment)
 
 		}
 	}
@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *Handler) liveness(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "ok")
+}
+
+func (h *Handler) readiness(ctx *gin.Context) {
+	readiness, err := h.services.Health.Readiness(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusServiceUnavailable, readiness)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, readiness)
+}
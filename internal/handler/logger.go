@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"tender-service/internal/logger"
+	"time"
+)
+
+const (
+	requestIdHeader = "X-Request-ID"
+	maxRequestIdLen = 128
+
+	requestIdCtx = "requestId"
+)
+
+func (h *Handler) requestLogger(ctx *gin.Context) {
+	requestId := ctx.GetHeader(requestIdHeader)
+	if !requestIdValid(requestId) {
+		requestId = uuid.NewString()
+	}
+	ctx.Set(requestIdCtx, requestId)
+	ctx.Header(requestIdHeader, requestId)
+
+	fields := logrus.Fields{logger.RequestIdField: requestId}
+	if spanCtx := trace.SpanContextFromContext(ctx.Request.Context()); spanCtx.HasTraceID() {
+		fields[logger.TraceIdField] = spanCtx.TraceID().String()
+	}
+	if tenderId := ctx.Param("tenderId"); tenderId != "" {
+		fields[logger.TenderIdField] = tenderId
+	}
+	if bidId := ctx.Param("bidId"); bidId != "" {
+		fields[logger.BidIdField] = bidId
+	}
+	ctx.Request = ctx.Request.WithContext(logger.WithFields(ctx.Request.Context(), fields))
+
+	start := time.Now()
+	ctx.Next()
+
+	status := ctx.Writer.Status()
+	entry := logger.FromContext(ctx.Request.Context()).WithFields(logrus.Fields{
+		"method":     ctx.Request.Method,
+		"path":       ctx.Request.URL.Path,
+		"route":      ctx.FullPath(),
+		"status":     status,
+		"latency_ms": time.Since(start).Milliseconds(),
+		"client_ip":  ctx.ClientIP(),
+		"user_agent": ctx.Request.UserAgent(),
+		"bytes":      max(ctx.Writer.Size(), 0),
+	})
+	switch {
+	case status >= http.StatusInternalServerError:
+		entry.Error("http request")
+	case status >= http.StatusBadRequest:
+		entry.Warn("http request")
+	default:
+		entry.Info("http request")
+	}
+}
+
+func requestIdValid(requestId string) bool {
+	if requestId == "" || len(requestId) > maxRequestIdLen {
+		return false
+	}
+	for _, r := range requestId {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
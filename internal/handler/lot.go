@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"tender-service/internal/entity"
+)
+
+func (h *Handler) getTenderLots(ctx *gin.Context) {
+	tenderId := ctx.Param("tenderId")
+	if err := tenderIdValidate(tenderId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var userId *string
+	if id, err := getUserId(ctx); err == nil {
+		userId = &id
+	}
+
+	lots, err := h.services.Lot.GetLots(ctx.Request.Context(), tenderId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lots)
+}
+
+func (h *Handler) addTenderLots(ctx *gin.Context) {
+	tenderId := ctx.Param("tenderId")
+	if err := tenderIdValidate(tenderId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var input entity.AddLotsInput
+	if err = ctx.Bind(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	lots, err := h.services.Lot.AddLots(ctx.Request.Context(), tenderId, userId, input)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lots)
+}
+
+func (h *Handler) cancelTenderLot(ctx *gin.Context) {
+	tenderId := ctx.Param("tenderId")
+	if err := tenderIdValidate(tenderId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	lotId := ctx.Param("lotId")
+	if err := lotIdValidate(lotId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	lot, err := h.services.Lot.CancelLot(ctx.Request.Context(), tenderId, lotId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lot)
+}
+
+func lotIdValidate(lotId string) error {
+	if lotId == "" {
+		return fmt.Errorf("lotId is empty")
+	}
+	if len(lotId) > 100 {
+		return fmt.Errorf("lotId is too long, maxLength=100")
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+	"tender-service/internal/logger"
+	"tender-service/internal/service"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer"
+	organizationHeader  = "X-Organization-Id"
+	organizationQuery   = "organizationId"
+
+	userIdCtx = "userId"
+)
+
+var (
+	errEmptyAuthHeader   = fmt.Errorf("empty auth header")
+	errInvalidAuthHeader = fmt.Errorf("invalid auth header")
+	errUserIdNotFound    = fmt.Errorf("user id not found in context")
+)
+
+func (h *Handler) userIdentity(ctx *gin.Context) {
+	userId, err := h.identifyUser(ctx)
+	if err != nil {
+		newServiceErrorResponseWithStatus(ctx, http.StatusUnauthorized, err)
+		return
+	}
+
+	ctx.Set(userIdCtx, userId)
+	ctx.Request = ctx.Request.WithContext(logger.WithField(ctx.Request.Context(), logger.UserIdField, userId))
+}
+
+func (h *Handler) optionalUserIdentity(ctx *gin.Context) {
+	if ctx.GetHeader(authorizationHeader) == "" && (!h.usernameMode || ctx.Query("username") == "") {
+		return
+	}
+
+	h.userIdentity(ctx)
+}
+
+func (h *Handler) organizationSelector(ctx *gin.Context) {
+	organizationId := ctx.GetHeader(organizationHeader)
+	if organizationId == "" {
+		organizationId = ctx.Query(organizationQuery)
+	}
+	if organizationId == "" {
+		return
+	}
+	if err := organizationIdValidate(organizationId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx.Request = ctx.Request.WithContext(service.WithOrganizationId(ctx.Request.Context(), organizationId))
+}
+
+func (h *Handler) identifyUser(ctx *gin.Context) (string, error) {
+	header := ctx.GetHeader(authorizationHeader)
+	if header == "" {
+		if !h.usernameMode {
+			return "", errEmptyAuthHeader
+		}
+
+		username := ctx.Query("username")
+		if err := usernameValidate(username); err != nil {
+			return "", err
+		}
+		return h.services.Auth.GetUserId(ctx.Request.Context(), username)
+	}
+
+	headerParts := strings.Split(header, " ")
+	if len(headerParts) != 2 || headerParts[0] != bearerPrefix || headerParts[1] == "" {
+		return "", errInvalidAuthHeader
+	}
+
+	return h.services.Auth.ParseToken(ctx.Request.Context(), headerParts[1])
+}
+
+func getUserId(ctx *gin.Context) (string, error) {
+	userId, ok := ctx.Get(userIdCtx)
+	if !ok {
+		return "", errUserIdNotFound
+	}
+
+	id, ok := userId.(string)
+	if !ok {
+		return "", errUserIdNotFound
+	}
+
+	return id, nil
+}
+
+func organizationIdValidate(organizationId string) error {
+	if len(organizationId) > 100 {
+		return fmt.Errorf("organizationId is too long, maxLength=100")
+	}
+	return nil
+}
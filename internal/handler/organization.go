@@ -0,0 +1,332 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"slices"
+	"strconv"
+	"tender-service/internal/entity"
+)
+
+func (h *Handler) createOrganization(ctx *gin.Context) {
+	var input entity.CreateOrganizationInput
+
+	if err := ctx.Bind(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	organization, err := h.services.Organization.CreateOrganization(ctx.Request.Context(), userId, input)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, organization)
+}
+
+func (h *Handler) getOrganizations(ctx *gin.Context) {
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "5"))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := limitValidate(limit); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := offsetValidate(offset); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	organizations, err := h.services.Organization.GetOrganizations(ctx.Request.Context(), limit, offset)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, organizations)
+}
+
+func (h *Handler) getOrganization(ctx *gin.Context) {
+	organizationId := ctx.Param("organizationId")
+	if err := organizationIdParamValidate(organizationId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	organization, err := h.services.Organization.GetOrganizationById(ctx.Request.Context(), organizationId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, organization)
+}
+
+func (h *Handler) editOrganization(ctx *gin.Context) {
+	organizationId := ctx.Param("organizationId")
+	if err := organizationIdParamValidate(organizationId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var input entity.EditOrganizationInput
+	if err = ctx.Bind(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if input.Name == nil && input.Description == nil && input.Type == nil {
+		newErrorResponse(ctx, http.StatusBadRequest, "no parameters for edit")
+		return
+	}
+	if input.Name != nil {
+		if err := organizationNameValidate(*input.Name); err != nil {
+			newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if input.Description != nil {
+		if err := organizationDescriptionValidate(*input.Description); err != nil {
+			newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if input.Type != nil {
+		if err := organizationTypeValidate(*input.Type); err != nil {
+			newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	organization, err := h.services.Organization.EditOrganization(ctx.Request.Context(), organizationId, userId, input)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, organization)
+}
+
+func (h *Handler) deleteOrganization(ctx *gin.Context) {
+	organizationId := ctx.Param("organizationId")
+	if err := organizationIdParamValidate(organizationId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	err = h.services.Organization.DeleteOrganization(ctx.Request.Context(), organizationId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *Handler) addResponsible(ctx *gin.Context) {
+	organizationId := ctx.Param("organizationId")
+	if err := organizationIdParamValidate(organizationId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var input entity.AddResponsibleInput
+	if err := ctx.Bind(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	members, err := h.services.Organization.AddResponsible(ctx.Request.Context(), organizationId, userId, input)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, members)
+}
+
+func (h *Handler) removeResponsible(ctx *gin.Context) {
+	organizationId := ctx.Param("organizationId")
+	if err := organizationIdParamValidate(organizationId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	memberId := ctx.Param("userId")
+	if err := memberIdValidate(memberId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	err = h.services.Organization.RemoveResponsible(ctx.Request.Context(), organizationId, memberId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *Handler) getOrganizationRoles(ctx *gin.Context) {
+	organizationId := ctx.Param("organizationId")
+	if err := organizationIdParamValidate(organizationId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	members, err := h.services.Role.GetOrganizationRoles(ctx.Request.Context(), organizationId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, members)
+}
+
+func (h *Handler) grantRole(ctx *gin.Context) {
+	organizationId := ctx.Param("organizationId")
+	if err := organizationIdParamValidate(organizationId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	memberId := ctx.Param("userId")
+	if err := memberIdValidate(memberId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var input entity.GrantRoleInput
+	if err := ctx.Bind(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	member, err := h.services.Role.GrantRole(ctx.Request.Context(), organizationId, memberId, input.Role, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
+
+func (h *Handler) revokeRole(ctx *gin.Context) {
+	organizationId := ctx.Param("organizationId")
+	if err := organizationIdParamValidate(organizationId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	memberId := ctx.Param("userId")
+	if err := memberIdValidate(memberId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	member, err := h.services.Role.RevokeRole(ctx.Request.Context(), organizationId, memberId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
+
+func organizationIdParamValidate(organizationId string) error {
+	if organizationId == "" {
+		return fmt.Errorf("organizationId is empty")
+	}
+	return organizationIdValidate(organizationId)
+}
+
+func memberIdValidate(userId string) error {
+	if userId == "" {
+		return fmt.Errorf("userId is empty")
+	}
+	if len(userId) > 100 {
+		return fmt.Errorf("userId is too long, maxLength=100")
+	}
+	return nil
+}
+
+func organizationNameValidate(name string) error {
+	if name == "" {
+		return fmt.Errorf("organizationName is empty")
+	}
+	if len(name) > 100 {
+		return fmt.Errorf("organizationName is too long, maxLength=100")
+	}
+	return nil
+}
+
+func organizationDescriptionValidate(description string) error {
+	if len(description) > 500 {
+		return fmt.Errorf("organizationDescription is too long, maxLength=500")
+	}
+	return nil
+}
+
+func organizationTypeValidate(organizationType string) error {
+	if !slices.Contains([]string{"IE", "LLC", "JSC"}, organizationType) {
+		return fmt.Errorf("invalid type, must be 'IE'/'LLC'/'JSC'")
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"tender-service/internal/entity"
+)
+
+type tenderBidsPage struct {
+	entity.Page[entity.Bid]
+	Sealed bool `json:"sealed"`
+}
+
+func pageFromQuery(ctx *gin.Context) (entity.PageRequest, error) {
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "5"))
+	if err != nil {
+		return entity.PageRequest{}, err
+	}
+	if err := limitValidate(limit); err != nil {
+		return entity.PageRequest{}, err
+	}
+
+	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if err != nil {
+		return entity.PageRequest{}, err
+	}
+	if err := offsetValidate(offset); err != nil {
+		return entity.PageRequest{}, err
+	}
+
+	page := entity.PageRequest{Limit: limit, Offset: offset}
+	if cursor, ok := ctx.GetQuery("cursor"); ok {
+		if len(cursor) > 500 {
+			return entity.PageRequest{}, fmt.Errorf("cursor is too long, maxLength=500")
+		}
+		if offset != 0 {
+			return entity.PageRequest{}, fmt.Errorf("cursor and offset cannot be used together")
+		}
+		page.Cursor = &cursor
+	}
+
+	return page, nil
+}
+
+func pageResponse[T any](ctx *gin.Context, page entity.PageRequest, result entity.Page[T]) {
+	if page.Cursor == nil {
+		ctx.JSON(http.StatusOK, result.Items)
+		return
+	}
+	ctx.JSON(http.StatusOK, result)
+}
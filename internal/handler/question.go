@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"tender-service/internal/entity"
+)
+
+func (h *Handler) getTenderQuestions(ctx *gin.Context) {
+	tenderId := ctx.Param("tenderId")
+	if err := tenderIdValidate(tenderId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var userId *string
+	if id, err := getUserId(ctx); err == nil {
+		userId = &id
+	}
+
+	questions, err := h.services.Question.GetQuestions(ctx.Request.Context(), tenderId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, questions)
+}
+
+func (h *Handler) askTenderQuestion(ctx *gin.Context) {
+	tenderId := ctx.Param("tenderId")
+	if err := tenderIdValidate(tenderId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var input entity.AskQuestionInput
+	if err = ctx.Bind(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	question, err := h.services.Question.AskQuestion(ctx.Request.Context(), tenderId, userId, input)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, question)
+}
+
+func (h *Handler) answerTenderQuestion(ctx *gin.Context) {
+	tenderId := ctx.Param("tenderId")
+	if err := tenderIdValidate(tenderId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	questionId := ctx.Param("questionId")
+	if err := questionIdValidate(questionId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var input entity.AnswerQuestionInput
+	if err = ctx.Bind(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	question, err := h.services.Question.AnswerQuestion(ctx.Request.Context(), tenderId, questionId, userId, input)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, question)
+}
+
+func questionIdValidate(questionId string) error {
+	if questionId == "" {
+		return fmt.Errorf("questionId is empty")
+	}
+	if len(questionId) > 100 {
+		return fmt.Errorf("questionId is too long, maxLength=100")
+	}
+	return nil
+}
@@ -1,15 +1,75 @@
 package handler
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"strings"
+	"tender-service/internal/logger"
+	"tender-service/internal/service"
 )
 
 type errorResponse struct {
-	Reason string `json:"reason"`
+	Code           string         `json:"code"`
+	Reason         string         `json:"reason"`
+	Details        map[string]any `json:"details,omitempty"`
+	CurrentVersion *int           `json:"currentVersion,omitempty"`
 }
 
 func newErrorResponse(ctx *gin.Context, statusCode int, message string) {
-	logrus.Error(message)
-	ctx.AbortWithStatusJSON(statusCode, errorResponse{Reason: message})
+	abortWithError(ctx, statusCode, errorResponse{Code: statusErrorCode(statusCode), Reason: message})
+}
+
+func newServiceErrorResponse(ctx *gin.Context, err error) {
+	newServiceErrorResponseWithStatus(ctx, 0, err)
+}
+
+func newServiceErrorResponseWithStatus(ctx *gin.Context, statusCode int, err error) {
+	var serviceErr *service.Error
+	if !errors.As(err, &serviceErr) {
+		if statusCode == 0 {
+			statusCode = http.StatusInternalServerError
+		}
+		newErrorResponse(ctx, statusCode, err.Error())
+		return
+	}
+	if statusCode == 0 {
+		statusCode = serviceErr.Status
+	}
+
+	response := errorResponse{
+		Code:    serviceErr.Code,
+		Reason:  err.Error(),
+		Details: serviceErr.Details,
+	}
+	var conflict *service.VersionConflictError
+	if errors.As(err, &conflict) {
+		setVersionTag(ctx, conflict.CurrentVersion)
+		response.CurrentVersion = &conflict.CurrentVersion
+	}
+	abortWithError(ctx, statusCode, response)
+}
+
+func abortWithError(ctx *gin.Context, statusCode int, response errorResponse) {
+	logger.FromContext(ctx.Request.Context()).WithFields(logrus.Fields{
+		"status": statusCode,
+		"code":   response.Code,
+	}).Error(response.Reason)
+	ctx.AbortWithStatusJSON(statusCode, response)
+}
+
+func statusErrorCode(statusCode int) string {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return "VALIDATION_ERROR"
+	case http.StatusInternalServerError:
+		return "INTERNAL_ERROR"
+	}
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(statusCode), " ", "_"))
+}
+
+func setVersionTag(ctx *gin.Context, version int) {
+	ctx.Header("ETag", strconv.Quote(strconv.Itoa(version)))
 }
@@ -4,49 +4,36 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 	"net/http"
 	"slices"
 	"strconv"
+	"strings"
 	"tender-service/internal/entity"
 	"tender-service/internal/service"
+	"time"
 )
 
 func (h *Handler) getTenders(ctx *gin.Context) {
-	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "5"))
+	page, err := pageFromQuery(ctx)
 	if err != nil {
 		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
-	if err := limitValidate(limit); err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
 
-	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	filter, err := tenderFilterFromQuery(ctx)
 	if err != nil {
 		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
-	if err := offsetValidate(offset); err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
 
-	serviceType, ok := ctx.GetQueryArray("service_type")
-	if ok {
-		if err := serviceTypeValidate(serviceType); err != nil {
-			newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-			return
-		}
-	}
-
-	tenders, err := h.services.Tender.GetTenders(ctx.Request.Context(), limit, offset, serviceType)
+	tenders, err := h.services.Tender.GetTenders(ctx.Request.Context(), page, filter)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		newServiceErrorResponse(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, tenders)
+	pageResponse(ctx, page, tenders)
 }
 
 func (h *Handler) createTender(ctx *gin.Context) {
@@ -57,28 +44,24 @@ func (h *Handler) createTender(ctx *gin.Context) {
 		return
 	}
 
-	userId, err := h.services.Auth.GetUserId(ctx.Request.Context(), input.CreatorUsername)
+	userId, err := getUserId(ctx)
 	if err != nil {
-		if errors.Is(err, service.ErrUserNotFound) {
-			newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
 		return
 	}
 	ok, err := h.services.Auth.CheckResponsibility(ctx.Request.Context(), userId, input.OrganizationId)
 	if !ok {
 		if errors.Is(err, service.ErrOrganizationNotFound) {
-			newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+			newServiceErrorResponseWithStatus(ctx, http.StatusBadRequest, err)
 			return
 		}
-		newErrorResponse(ctx, http.StatusForbidden, err.Error())
+		newServiceErrorResponseWithStatus(ctx, http.StatusForbidden, err)
 		return
 	}
 
 	tender, err := h.services.Tender.CreateTender(ctx.Request.Context(), userId, input)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newServiceErrorResponse(ctx, err)
 		return
 	}
 
@@ -86,48 +69,25 @@ func (h *Handler) createTender(ctx *gin.Context) {
 }
 
 func (h *Handler) getUserTenders(ctx *gin.Context) {
-	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "5"))
+	page, err := pageFromQuery(ctx)
 	if err != nil {
 		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
-	if err := limitValidate(limit); err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
 
-	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	userId, err := getUserId(ctx)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
-	if err := offsetValidate(offset); err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
 		return
 	}
 
-	username := ctx.Query("username")
-	if err := usernameValidate(username); err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
-	userId, err := h.services.Auth.GetUserId(ctx.Request.Context(), username)
-	if err != nil {
-		if errors.Is(err, service.ErrUserNotFound) {
-			newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
-
-	tenders, err := h.services.Tender.GetTendersByUserId(ctx.Request.Context(), limit, offset, userId)
+	tenders, err := h.services.Tender.GetTendersByUserId(ctx.Request.Context(), page, userId)
 	if err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newServiceErrorResponse(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, tenders)
+	pageResponse(ctx, page, tenders)
 }
 
 func (h *Handler) getTenderStatus(ctx *gin.Context) {
@@ -137,28 +97,14 @@ func (h *Handler) getTenderStatus(ctx *gin.Context) {
 		return
 	}
 
-	username := ctx.Query("username")
 	var userId *string
-	if username != "" {
-		id, err := h.services.Auth.GetUserId(ctx.Request.Context(), username)
-		if err != nil {
-			newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
+	if id, err := getUserId(ctx); err == nil {
 		userId = &id
 	}
 
 	status, err := h.services.Tender.GetTenderStatus(ctx.Request.Context(), tenderId, userId)
 	if err != nil {
-		if errors.Is(err, service.ErrTenderNotFound) {
-			newErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		if errors.Is(err, service.ErrNotEnoughPermissions) {
-			newErrorResponse(ctx, http.StatusForbidden, err.Error())
-			return
-		}
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newServiceErrorResponse(ctx, err)
 		return
 	}
 
@@ -178,32 +124,15 @@ func (h *Handler) updateTenderStatus(ctx *gin.Context) {
 		return
 	}
 
-	username := ctx.Query("username")
-	if err := usernameValidate(username); err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
-	userId, err := h.services.Auth.GetUserId(ctx.Request.Context(), username)
+	userId, err := getUserId(ctx)
 	if err != nil {
-		if errors.Is(err, service.ErrUserNotFound) {
-			newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
 		return
 	}
 
 	tender, err := h.services.Tender.UpdateTenderStatus(ctx.Request.Context(), tenderId, status, userId)
 	if err != nil {
-		if errors.Is(err, service.ErrTenderNotFound) {
-			newErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		if errors.Is(err, service.ErrNotEnoughPermissions) {
-			newErrorResponse(ctx, http.StatusForbidden, err.Error())
-			return
-		}
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newServiceErrorResponse(ctx, err)
 		return
 	}
 
@@ -217,18 +146,9 @@ func (h *Handler) editTender(ctx *gin.Context) {
 		return
 	}
 
-	username := ctx.Query("username")
-	if err := usernameValidate(username); err != nil {
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
-		return
-	}
-	userId, err := h.services.Auth.GetUserId(ctx.Request.Context(), username)
+	userId, err := getUserId(ctx)
 	if err != nil {
-		if errors.Is(err, service.ErrUserNotFound) {
-			newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
 		return
 	}
 
@@ -237,7 +157,8 @@ func (h *Handler) editTender(ctx *gin.Context) {
 		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
-	if input.Name == nil && input.Description == nil && input.ServiceType == nil {
+	if input.Name == nil && input.Description == nil && input.ServiceType == nil && input.SubmissionDeadline == nil &&
+		input.QuestionDeadline == nil && input.Budget == nil && input.Currency == nil {
 		newErrorResponse(ctx, http.StatusBadRequest, "no parameters for edit")
 		return
 	}
@@ -259,21 +180,19 @@ func (h *Handler) editTender(ctx *gin.Context) {
 			return
 		}
 	}
+	input.Version, err = expectedVersion(ctx, input.Version)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	tender, err := h.services.Tender.EditTender(ctx.Request.Context(), tenderId, userId, input)
 	if err != nil {
-		if errors.Is(err, service.ErrTenderNotFound) {
-			newErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		if errors.Is(err, service.ErrNotEnoughPermissions) {
-			newErrorResponse(ctx, http.StatusForbidden, err.Error())
-			return
-		}
-		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		newServiceErrorResponse(ctx, err)
 		return
 	}
 
+	setVersionTag(ctx, tender.Version)
 	ctx.JSON(http.StatusOK, tender)
 }
 
@@ -294,38 +213,126 @@ func (h *Handler) rollbackTender(ctx *gin.Context) {
 		return
 	}
 
-	username := ctx.Query("username")
-	if err := usernameValidate(username); err != nil {
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	tender, err := h.services.Tender.RollbackTender(ctx.Request.Context(), tenderId, version, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tender)
+}
+
+func (h *Handler) openTenderBids(ctx *gin.Context) {
+	tenderId := ctx.Param("tenderId")
+	if err := tenderIdValidate(tenderId); err != nil {
 		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
-	userId, err := h.services.Auth.GetUserId(ctx.Request.Context(), username)
+
+	userId, err := getUserId(ctx)
 	if err != nil {
-		if errors.Is(err, service.ErrUserNotFound) {
-			newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
-			return
-		}
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	tender, err := h.services.Tender.OpenBids(ctx.Request.Context(), tenderId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tender)
+}
+
+func (h *Handler) getTenderVersions(ctx *gin.Context) {
+	tenderId := ctx.Param("tenderId")
+	if err := tenderIdValidate(tenderId); err != nil {
 		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	tender, err := h.services.Tender.RollbackTender(ctx.Request.Context(), tenderId, version, userId)
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	versions, err := h.services.Tender.GetTenderVersions(ctx.Request.Context(), tenderId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, versions)
+}
+
+func (h *Handler) getTenderVersion(ctx *gin.Context) {
+	tenderId := ctx.Param("tenderId")
+	if err := tenderIdValidate(tenderId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	version, err := parseVersion(ctx.Param("version"))
 	if err != nil {
-		if errors.Is(err, service.ErrTenderNotFound) {
-			newErrorResponse(ctx, http.StatusNotFound, err.Error())
-			return
-		}
-		if errors.Is(err, service.ErrNotEnoughPermissions) {
-			newErrorResponse(ctx, http.StatusForbidden, err.Error())
-			return
-		}
 		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	tender, err := h.services.Tender.GetTenderVersion(ctx.Request.Context(), tenderId, version, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
 	ctx.JSON(http.StatusOK, tender)
 }
 
+func (h *Handler) diffTenderVersions(ctx *gin.Context) {
+	tenderId := ctx.Param("tenderId")
+	if err := tenderIdValidate(tenderId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	from, err := parseVersion(ctx.Query("from"))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, "from: "+err.Error())
+		return
+	}
+	to, err := parseVersion(ctx.Query("to"))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, "to: "+err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	diff, err := h.services.Tender.DiffTenderVersions(ctx.Request.Context(), tenderId, from, to, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, diff)
+}
+
 func limitValidate(limit int) error {
 	if limit < 0 || limit > 50 {
 		return fmt.Errorf("invalid limit, min = 0, max = 50")
@@ -376,6 +383,70 @@ func usernameValidate(username string) error {
 	return nil
 }
 
+func tenderFilterFromQuery(ctx *gin.Context) (entity.TenderFilter, error) {
+	filter := entity.TenderFilter{
+		SortBy:    ctx.DefaultQuery("sort", "name"),
+		SortOrder: ctx.DefaultQuery("order", "asc"),
+	}
+	if !slices.Contains([]string{"name", "created_at", "deadline"}, filter.SortBy) {
+		return entity.TenderFilter{}, fmt.Errorf("invalid sort, must be 'name'/'created_at'/'deadline'")
+	}
+	if !slices.Contains([]string{"asc", "desc"}, filter.SortOrder) {
+		return entity.TenderFilter{}, fmt.Errorf("invalid order, must be 'asc'/'desc'")
+	}
+
+	if serviceType, ok := ctx.GetQueryArray("service_type"); ok {
+		if err := serviceTypeValidate(serviceType); err != nil {
+			return entity.TenderFilter{}, err
+		}
+		filter.ServiceType = serviceType
+	}
+
+	filter.Query = strings.TrimSpace(ctx.Query("q"))
+	if len(filter.Query) > 200 {
+		return entity.TenderFilter{}, fmt.Errorf("q is too long, maxLength=200")
+	}
+
+	filter.OrganizationId = ctx.Query("organization_id")
+	if len(filter.OrganizationId) > 100 {
+		return entity.TenderFilter{}, fmt.Errorf("organization_id is too long, maxLength=100")
+	}
+
+	if value, ok := ctx.GetQuery("created_from"); ok {
+		createdFrom, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return entity.TenderFilter{}, fmt.Errorf("invalid created_from, must be an RFC 3339 timestamp")
+		}
+		createdFrom = createdFrom.UTC()
+		filter.CreatedFrom = &createdFrom
+	}
+	if value, ok := ctx.GetQuery("created_to"); ok {
+		createdTo, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return entity.TenderFilter{}, fmt.Errorf("invalid created_to, must be an RFC 3339 timestamp")
+		}
+		createdTo = createdTo.UTC()
+		filter.CreatedTo = &createdTo
+	}
+
+	if value, ok := ctx.GetQuery("min_budget"); ok {
+		minBudget, err := decimal.NewFromString(value)
+		if err != nil {
+			return entity.TenderFilter{}, fmt.Errorf("invalid min_budget, must be a decimal number")
+		}
+		filter.MinBudget = &minBudget
+	}
+	if value, ok := ctx.GetQuery("max_budget"); ok {
+		maxBudget, err := decimal.NewFromString(value)
+		if err != nil {
+			return entity.TenderFilter{}, fmt.Errorf("invalid max_budget, must be a decimal number")
+		}
+		filter.MaxBudget = &maxBudget
+	}
+
+	return filter, nil
+}
+
 func tenderIdValidate(tenderId string) error {
 	if tenderId == "" {
 		return fmt.Errorf("tenderId is empty")
@@ -396,9 +467,45 @@ func tenderStatusValidate(status string) error {
 	return nil
 }
 
+func parseVersion(value string) (int, error) {
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid version, must be a number")
+	}
+	if err := versionValidate(version); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
 func versionValidate(version int) error {
 	if version < 1 {
 		return fmt.Errorf("invalid version, min = 1")
 	}
 	return nil
 }
+
+func expectedVersion(ctx *gin.Context, bodyVersion *int) (*int, error) {
+	if bodyVersion != nil {
+		if err := versionValidate(*bodyVersion); err != nil {
+			return nil, err
+		}
+	}
+
+	ifMatch := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return bodyVersion, nil
+	}
+
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`))
+	if err != nil {
+		return nil, fmt.Errorf("invalid If-Match header, must be a version number")
+	}
+	if err := versionValidate(version); err != nil {
+		return nil, err
+	}
+	if bodyVersion != nil && *bodyVersion != version {
+		return nil, fmt.Errorf("If-Match header and version do not match")
+	}
+	return &version, nil
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+)
+
+var tracer = otel.Tracer("tender-service/internal/handler")
+
+func (h *Handler) requestTracing(ctx *gin.Context) {
+	parent := otel.GetTextMapPropagator().Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+
+	route := ctx.FullPath()
+	if route == "" {
+		route = unmatchedRoute
+	}
+	spanCtx, span := tracer.Start(parent, ctx.Request.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(ctx.Request.Method),
+			semconv.HTTPRoute(route),
+			semconv.URLPath(ctx.Request.URL.Path),
+			semconv.ClientAddress(ctx.ClientIP()),
+			semconv.UserAgentOriginal(ctx.Request.UserAgent()),
+		),
+	)
+	defer span.End()
+
+	ctx.Request = ctx.Request.WithContext(spanCtx)
+	ctx.Next()
+
+	status := ctx.Writer.Status()
+	span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+	if userId, ok := ctx.Get(userIdCtx); ok {
+		span.SetAttributes(semconv.EnduserID(fmt.Sprint(userId)))
+	}
+	if status >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(status))
+	}
+}
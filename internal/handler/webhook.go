@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/url"
+	"strconv"
+	"tender-service/internal/entity"
+)
+
+func (h *Handler) createWebhook(ctx *gin.Context) {
+	organizationId := ctx.Param("organizationId")
+	if err := organizationIdParamValidate(organizationId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var input entity.CreateWebhookInput
+	if err = ctx.Bind(&input); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := webhookUrlValidate(input.Url); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhook, err := h.services.Webhook.CreateWebhook(ctx.Request.Context(), organizationId, userId, input)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhook)
+}
+
+func (h *Handler) getWebhooks(ctx *gin.Context) {
+	organizationId := ctx.Param("organizationId")
+	if err := organizationIdParamValidate(organizationId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	webhooks, err := h.services.Webhook.GetWebhooks(ctx.Request.Context(), organizationId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhooks)
+}
+
+func (h *Handler) deleteWebhook(ctx *gin.Context) {
+	organizationId := ctx.Param("organizationId")
+	if err := organizationIdParamValidate(organizationId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhookId := ctx.Param("webhookId")
+	if err := webhookIdValidate(webhookId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	err = h.services.Webhook.DeleteWebhook(ctx.Request.Context(), organizationId, webhookId, userId)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *Handler) getWebhookDeliveries(ctx *gin.Context) {
+	organizationId := ctx.Param("organizationId")
+	if err := organizationIdParamValidate(organizationId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhookId := ctx.Param("webhookId")
+	if err := webhookIdValidate(webhookId); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "5"))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := limitValidate(limit); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := offsetValidate(offset); err != nil {
+		newErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, err := getUserId(ctx)
+	if err != nil {
+		newErrorResponse(ctx, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	deliveries, err := h.services.Webhook.GetDeliveries(ctx.Request.Context(), organizationId, webhookId, userId, limit, offset)
+	if err != nil {
+		newServiceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+func webhookIdValidate(webhookId string) error {
+	if webhookId == "" {
+		return fmt.Errorf("webhookId is empty")
+	}
+	if len(webhookId) > 100 {
+		return fmt.Errorf("webhookId is too long, maxLength=100")
+	}
+	return nil
+}
+
+func webhookUrlValidate(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("invalid webhook url: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("webhook url must use http or https scheme")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("webhook url must contain a host")
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package logger
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	RequestIdField = "request_id"
+	TraceIdField   = "trace_id"
+	UserIdField    = "user_id"
+	TenderIdField  = "tender_id"
+	BidIdField     = "bid_id"
+)
+
+type loggerCtxKey struct{}
+
+func WithLogger(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, entry)
+}
+
+func WithFields(ctx context.Context, fields logrus.Fields) context.Context {
+	return WithLogger(ctx, FromContext(ctx).WithFields(fields))
+}
+
+func WithField(ctx context.Context, key string, value any) context.Context {
+	return WithLogger(ctx, FromContext(ctx).WithField(key, value))
+}
+
+func FromContext(ctx context.Context) *logrus.Entry {
+	if entry, ok := ctx.Value(loggerCtxKey{}).(*logrus.Entry); ok {
+		return entry
+	}
+	return logrus.NewEntry(logrus.StandardLogger())
+}
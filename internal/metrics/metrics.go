@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	tendersCreated   prometheus.Counter
+	tendersPublished prometheus.Counter
+	tendersClosed    prometheus.Counter
+	bidsCreated      prometheus.Counter
+	bidDecisions     *prometheus.CounterVec
+	bidsDecided      *prometheus.CounterVec
+}
+
+func New(namespace string) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+
+		tendersCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tenders_created_total",
+			Help:      "Number of created tenders.",
+		}),
+		tendersPublished: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tenders_published_total",
+			Help:      "Number of published tenders.",
+		}),
+		tendersClosed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tenders_closed_total",
+			Help:      "Number of closed tenders, including closing by deadline and by awarding all lots.",
+		}),
+		bidsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bids_created_total",
+			Help:      "Number of created bids.",
+		}),
+		bidDecisions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bid_decisions_total",
+			Help:      "Number of submitted bid decisions by decision.",
+		}, []string{"decision"}),
+		bidsDecided: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bids_decided_total",
+			Help:      "Number of bids that reached a final decision by outcome.",
+		}, []string{"outcome"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.tendersCreated,
+		m.tendersPublished,
+		m.tendersClosed,
+		m.bidsCreated,
+		m.bidDecisions,
+		m.bidsDecided,
+	)
+
+	return m
+}
+
+func (m *Metrics) Register(collector prometheus.Collector) error {
+	return m.registry.Register(collector)
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) ObserveHTTPRequest(method string, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(method, route, code).Inc()
+	m.httpDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+func (m *Metrics) TenderCreated() {
+	m.tendersCreated.Inc()
+}
+
+func (m *Metrics) TenderPublished() {
+	m.tendersPublished.Inc()
+}
+
+func (m *Metrics) TendersClosed(count int) {
+	m.tendersClosed.Add(float64(count))
+}
+
+func (m *Metrics) BidCreated() {
+	m.bidsCreated.Inc()
+}
+
+func (m *Metrics) BidDecisionSubmitted(decision string) {
+	m.bidDecisions.WithLabelValues(decision).Inc()
+}
+
+func (m *Metrics) BidDecided(outcome string) {
+	m.bidsDecided.WithLabelValues(outcome).Inc()
+}
@@ -0,0 +1,30 @@
+package outbox
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	"tender-service/internal/entity"
+	"tender-service/internal/logger"
+)
+
+type LogSink struct{}
+
+func NewLogSink() *LogSink {
+	return &LogSink{}
+}
+
+func (s *LogSink) Name() string {
+	return "log"
+}
+
+func (s *LogSink) Handle(ctx context.Context, event entity.Event) error {
+	logger.FromContext(ctx).WithFields(logrus.Fields{
+		"event_id":  event.Id,
+		"event":     event.Type,
+		"tender_id": event.Data.TenderId,
+		"bid_id":    event.Data.BidId,
+		"status":    event.Data.Status,
+		"version":   event.Data.Version,
+	}).Info("domain event")
+	return nil
+}
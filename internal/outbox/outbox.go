@@ -0,0 +1,11 @@
+package outbox
+
+import (
+	"context"
+	"tender-service/internal/entity"
+)
+
+type Sink interface {
+	Name() string
+	Handle(ctx context.Context, event entity.Event) error
+}
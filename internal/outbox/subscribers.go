@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"tender-service/internal/entity"
+)
+
+type Handler func(ctx context.Context, event entity.Event) error
+
+type Subscribers struct {
+	mu       sync.RWMutex
+	nextId   int
+	handlers map[int]Handler
+}
+
+func NewSubscribers() *Subscribers {
+	return &Subscribers{handlers: make(map[int]Handler)}
+}
+
+func (s *Subscribers) Subscribe(handler Handler) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.nextId
+	s.nextId++
+	s.handlers[id] = handler
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.handlers, id)
+	}
+}
+
+func (s *Subscribers) Name() string {
+	return "subscribers"
+}
+
+func (s *Subscribers) Handle(ctx context.Context, event entity.Event) error {
+	s.mu.RLock()
+	handlers := make([]Handler, 0, len(s.handlers))
+	for _, handler := range s.handlers {
+		handlers = append(handlers, handler)
+	}
+	s.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,237 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"tender-service/internal/entity"
+	"tender-service/internal/repository/repoerrs"
+)
+
+type AttachmentRepository struct {
+	db *Postgres
+}
+
+func NewAttachmentRepository(db *Postgres) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+func (r *AttachmentRepository) CreateAttachment(ctx context.Context, attachment entity.Attachment) (entity.Attachment, error) {
+	ctx, span := tracer.Start(ctx, "AttachmentRepository.CreateAttachment")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Insert("attachments").
+		Columns("tender_id", "bid_id", "file_name", "content_type", "size", "checksum", "storage_key", "uploaded_by", "added_in_version").
+		Values(
+			attachment.TenderId,
+			attachment.BidId,
+			attachment.FileName,
+			attachment.ContentType,
+			attachment.Size,
+			attachment.Checksum,
+			attachment.StorageKey,
+			attachment.UploadedBy,
+			attachment.AddedInVersion,
+		).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	if err != nil {
+		return entity.Attachment{}, fmt.Errorf("AttachmentRepository.CreateAttachment  - r.db.Builder.ToSql: %v", err)
+	}
+
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&attachment.Id,
+		&attachment.CreatedAt,
+	)
+	if err != nil {
+		return entity.Attachment{}, fmt.Errorf("AttachmentRepository.CreateAttachment - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return attachment, nil
+}
+
+func (r *AttachmentRepository) GetAttachments(ctx context.Context, owner entity.AttachmentOwner, version int) ([]entity.Attachment, error) {
+	ctx, span := tracer.Start(ctx, "AttachmentRepository.GetAttachments")
+	defer span.End()
+
+	column, err := attachmentOwnerColumn(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := r.db.Builder.
+		Select("id", "tender_id", "bid_id", "file_name", "content_type", "size", "checksum", "storage_key", "uploaded_by", "added_in_version", "removed_in_version", "created_at").
+		From("attachments").
+		Where(column+" = ?", owner.Id).
+		Where("added_in_version <= ?", version).
+		Where(squirrel.Or{
+			squirrel.Eq{"removed_in_version": nil},
+			squirrel.Gt{"removed_in_version": version},
+		}).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentRepository.GetAttachments  - r.db.Builder.ToSql: %v", err)
+	}
+
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentRepository.GetAttachments - r.db.Conn().Query: %v", err)
+	}
+	defer rows.Close()
+
+	var attachments []entity.Attachment
+	for rows.Next() {
+		var attachment entity.Attachment
+		err := rows.Scan(
+			&attachment.Id,
+			&attachment.TenderId,
+			&attachment.BidId,
+			&attachment.FileName,
+			&attachment.ContentType,
+			&attachment.Size,
+			&attachment.Checksum,
+			&attachment.StorageKey,
+			&attachment.UploadedBy,
+			&attachment.AddedInVersion,
+			&attachment.RemovedInVersion,
+			&attachment.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("AttachmentRepository.GetAttachments - rows.Scan: %v", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, nil
+}
+
+func (r *AttachmentRepository) GetAttachmentById(ctx context.Context, id string) (entity.Attachment, error) {
+	ctx, span := tracer.Start(ctx, "AttachmentRepository.GetAttachmentById")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id", "tender_id", "bid_id", "file_name", "content_type", "size", "checksum", "storage_key", "uploaded_by", "added_in_version", "removed_in_version", "created_at").
+		From("attachments").
+		Where("id = ?", id).
+		ToSql()
+
+	if err != nil {
+		return entity.Attachment{}, fmt.Errorf("AttachmentRepository.GetAttachmentById  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var attachment entity.Attachment
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&attachment.Id,
+		&attachment.TenderId,
+		&attachment.BidId,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.Checksum,
+		&attachment.StorageKey,
+		&attachment.UploadedBy,
+		&attachment.AddedInVersion,
+		&attachment.RemovedInVersion,
+		&attachment.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Attachment{}, repoerrs.ErrNotFound
+		}
+		return entity.Attachment{}, fmt.Errorf("AttachmentRepository.GetAttachmentById - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return attachment, nil
+}
+
+func (r *AttachmentRepository) RemoveAttachment(ctx context.Context, id string, version int) error {
+	ctx, span := tracer.Start(ctx, "AttachmentRepository.RemoveAttachment")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Update("attachments").
+		Set("removed_in_version", version).
+		Where("id = ?", id).
+		Where("removed_in_version IS NULL").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("AttachmentRepository.RemoveAttachment  - r.db.Builder.ToSql: %v", err)
+	}
+
+	tag, err := r.db.Conn().Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("AttachmentRepository.RemoveAttachment - r.db.Conn().Exec: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *AttachmentRepository) RestoreAttachments(ctx context.Context, owner entity.AttachmentOwner, version int, newVersion int) error {
+	ctx, span := tracer.Start(ctx, "AttachmentRepository.RestoreAttachments")
+	defer span.End()
+
+	column, err := attachmentOwnerColumn(owner)
+	if err != nil {
+		return err
+	}
+
+	removeNewer, args, err := r.db.Builder.
+		Update("attachments").
+		Set("removed_in_version", newVersion).
+		Where(column+" = ?", owner.Id).
+		Where("removed_in_version IS NULL").
+		Where("added_in_version > ?", version).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("AttachmentRepository.RestoreAttachments  - r.db.Builder.ToSql: %v", err)
+	}
+
+	_, err = r.db.Conn().Exec(ctx, removeNewer, args...)
+	if err != nil {
+		return fmt.Errorf("AttachmentRepository.RestoreAttachments - r.db.Conn().Exec: %v", err)
+	}
+
+	restoreRemoved, args, err := r.db.Builder.
+		Insert("attachments").
+		Columns("tender_id", "bid_id", "file_name", "content_type", "size", "checksum", "storage_key", "uploaded_by", "added_in_version").
+		Select(r.db.Builder.
+			Select("tender_id", "bid_id", "file_name", "content_type", "size", "checksum", "storage_key", "uploaded_by").
+			Column("?::int", newVersion).
+			From("attachments").
+			Where(column+" = ?", owner.Id).
+			Where("added_in_version <= ?", version).
+			Where("removed_in_version > ?", version)).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("AttachmentRepository.RestoreAttachments  - r.db.Builder.ToSql: %v", err)
+	}
+
+	_, err = r.db.Conn().Exec(ctx, restoreRemoved, args...)
+	if err != nil {
+		return fmt.Errorf("AttachmentRepository.RestoreAttachments - r.db.Conn().Exec: %v", err)
+	}
+
+	return nil
+}
+
+func attachmentOwnerColumn(owner entity.AttachmentOwner) (string, error) {
+	switch owner.Type {
+	case entity.AttachmentOwnerTender:
+		return "tender_id", nil
+	case entity.AttachmentOwnerBid:
+		return "bid_id", nil
+	}
+	return "", fmt.Errorf("AttachmentRepository: unknown owner type %q", owner.Type)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/jackc/pgx/v5"
+	"tender-service/internal/entity"
 	"tender-service/internal/repository/repoerrs"
 )
 
@@ -17,6 +18,9 @@ func NewAuthRepository(db *Postgres) *AuthRepository {
 }
 
 func (r *AuthRepository) GetUserId(ctx context.Context, username string) (string, error) {
+	ctx, span := tracer.Start(ctx, "AuthRepository.GetUserId")
+	defer span.End()
+
 	query, args, err := r.db.Builder.
 		Select("id").
 		From("employee").
@@ -28,41 +32,188 @@ func (r *AuthRepository) GetUserId(ctx context.Context, username string) (string
 	}
 
 	var id string
-	err = r.db.Pool.QueryRow(ctx, query, args...).Scan(&id)
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(&id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return "", repoerrs.ErrNotFound
 		}
-		return "", fmt.Errorf("AuthRepository.GetUserId  - r.db.Pool.QueryRow: %v", err)
+		return "", fmt.Errorf("AuthRepository.GetUserId  - r.db.Conn().QueryRow: %v", err)
 	}
 
 	return id, nil
 }
 
-func (r *AuthRepository) GetUserOrganizationId(ctx context.Context, userId string) (string, error) {
+func (r *AuthRepository) GetUserCredentials(ctx context.Context, username string) (string, string, error) {
+	ctx, span := tracer.Start(ctx, "AuthRepository.GetUserCredentials")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id", "COALESCE(password_hash, '')").
+		From("employee").
+		Where("username = ?", username).
+		ToSql()
+
+	if err != nil {
+		return "", "", fmt.Errorf("AuthRepository.GetUserCredentials  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var id, passwordHash string
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(&id, &passwordHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", "", repoerrs.ErrNotFound
+		}
+		return "", "", fmt.Errorf("AuthRepository.GetUserCredentials  - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return id, passwordHash, nil
+}
+
+func (r *AuthRepository) GetUserMemberships(ctx context.Context, userId string) ([]entity.OrganizationMember, error) {
+	ctx, span := tracer.Start(ctx, "AuthRepository.GetUserMemberships")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("r.organization_id", "r.user_id", "e.username", "r.role").
+		From("organization_responsible r").
+		Join("employee e ON e.id = r.user_id").
+		Where("r.user_id = ?", userId).
+		OrderBy("r.organization_id ASC").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("AuthRepository.GetUserMemberships  - r.db.Builder.ToSql: %v", err)
+	}
+
+	members, err := r.queryMembers(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("AuthRepository.GetUserMemberships - r.queryMembers: %v", err)
+	}
+
+	if len(members) == 0 {
+		return nil, repoerrs.ErrNotFound
+	}
+
+	return members, nil
+}
+
+func (r *AuthRepository) GetOrganizationMembers(ctx context.Context, organizationId string) ([]entity.OrganizationMember, error) {
+	ctx, span := tracer.Start(ctx, "AuthRepository.GetOrganizationMembers")
+	defer span.End()
+
 	query, args, err := r.db.Builder.
-		Select("organization_id").
+		Select("r.organization_id", "r.user_id", "e.username", "r.role").
+		From("organization_responsible r").
+		Join("employee e ON e.id = r.user_id").
+		Where("r.organization_id = ?", organizationId).
+		OrderBy("e.username ASC").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("AuthRepository.GetOrganizationMembers  - r.db.Builder.ToSql: %v", err)
+	}
+
+	members, err := r.queryMembers(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("AuthRepository.GetOrganizationMembers - r.queryMembers: %v", err)
+	}
+
+	return members, nil
+}
+
+// LockOrganizationMembers locks the responsible rows of the organization until the end of the transaction so
+// that concurrent role changes see each other's result.
+func (r *AuthRepository) LockOrganizationMembers(ctx context.Context, organizationId string) error {
+	ctx, span := tracer.Start(ctx, "AuthRepository.LockOrganizationMembers")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id").
 		From("organization_responsible").
-		Where("user_id = ?", userId).
+		Where("organization_id = ?", organizationId).
+		OrderBy("id").
+		Suffix("FOR UPDATE").
 		ToSql()
 
 	if err != nil {
-		return "", fmt.Errorf("AuthRepository.GetUserOrganizationId  - r.db.Builder.ToSql: %v", err)
+		return fmt.Errorf("AuthRepository.LockOrganizationMembers  - r.db.Builder.ToSql: %v", err)
 	}
 
-	var id string
-	err = r.db.Pool.QueryRow(ctx, query, args...).Scan(&id)
+	_, err = r.db.Conn().Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("AuthRepository.LockOrganizationMembers - r.db.Conn().Exec: %v", err)
+	}
+
+	return nil
+}
+
+func (r *AuthRepository) UpdateMemberRole(ctx context.Context, organizationId string, userId string, role string) (entity.OrganizationMember, error) {
+	ctx, span := tracer.Start(ctx, "AuthRepository.UpdateMemberRole")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Update("organization_responsible r").
+		Set("role", role).
+		From("employee e").
+		Where("e.id = r.user_id").
+		Where("r.organization_id = ?", organizationId).
+		Where("r.user_id = ?", userId).
+		Suffix("RETURNING r.organization_id, r.user_id, e.username, r.role").
+		ToSql()
+
+	if err != nil {
+		return entity.OrganizationMember{}, fmt.Errorf("AuthRepository.UpdateMemberRole  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var member entity.OrganizationMember
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&member.OrganizationId,
+		&member.UserId,
+		&member.Username,
+		&member.Role,
+	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return "", repoerrs.ErrNotFound
+			return entity.OrganizationMember{}, repoerrs.ErrNotFound
 		}
-		return "", fmt.Errorf("AuthRepository.GetUserOrganizationId  - r.db.Pool.QueryRow: %v", err)
+		return entity.OrganizationMember{}, fmt.Errorf("AuthRepository.UpdateMemberRole  - r.db.Conn().QueryRow: %v", err)
 	}
 
-	return id, nil
+	return member, nil
+}
+
+func (r *AuthRepository) queryMembers(ctx context.Context, query string, args ...any) ([]entity.OrganizationMember, error) {
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.Conn().Query: %v", err)
+	}
+	defer rows.Close()
+
+	var members []entity.OrganizationMember
+	for rows.Next() {
+		var member entity.OrganizationMember
+		err := rows.Scan(
+			&member.OrganizationId,
+			&member.UserId,
+			&member.Username,
+			&member.Role,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("rows.Scan: %v", err)
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %v", err)
+	}
+
+	return members, nil
 }
 
 func (r *AuthRepository) OrganizationIsExist(ctx context.Context, organizationId string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "AuthRepository.OrganizationIsExist")
+	defer span.End()
+
 	query, args, err := r.db.Builder.
 		Select("id").
 		From("organization").
@@ -74,18 +225,21 @@ func (r *AuthRepository) OrganizationIsExist(ctx context.Context, organizationId
 	}
 
 	var id string
-	err = r.db.Pool.QueryRow(ctx, query, args...).Scan(&id)
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(&id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return false, repoerrs.ErrNotFound
 		}
-		return false, fmt.Errorf("AuthRepository.OrganizationIsExist  - r.db.Pool.QueryRow: %v", err)
+		return false, fmt.Errorf("AuthRepository.OrganizationIsExist  - r.db.Conn().QueryRow: %v", err)
 	}
 
 	return true, nil
 }
 
 func (r *AuthRepository) UserIsExist(ctx context.Context, userId string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "AuthRepository.UserIsExist")
+	defer span.End()
+
 	query, args, err := r.db.Builder.
 		Select("id").
 		From("employee").
@@ -97,12 +251,12 @@ func (r *AuthRepository) UserIsExist(ctx context.Context, userId string) (bool,
 	}
 
 	var id string
-	err = r.db.Pool.QueryRow(ctx, query, args...).Scan(&id)
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(&id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return false, repoerrs.ErrNotFound
 		}
-		return false, fmt.Errorf("AuthRepository.UserIsExist  - r.db.Pool.QueryRow: %v", err)
+		return false, fmt.Errorf("AuthRepository.UserIsExist  - r.db.Conn().QueryRow: %v", err)
 	}
 
 	return true, nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
 	"tender-service/internal/entity"
 	"tender-service/internal/repository/repoerrs"
@@ -18,9 +19,18 @@ func NewBidRepository(db *Postgres) *BidRepository {
 }
 
 func (r *BidRepository) CreateBid(ctx context.Context, bid entity.Bid) (entity.Bid, error) {
+	ctx, span := tracer.Start(ctx, "BidRepository.CreateBid")
+	defer span.End()
+
+	tx, err := r.db.Conn().Begin(ctx)
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("BidRepository.CreateBid - r.Pool.Begin: %v", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
 	query, args, err := r.db.Builder.
 		Insert("bids").
-		Columns("name", "description", "status", "tender_id", "author_type", "author_id", "version").
+		Columns("name", "description", "status", "tender_id", "author_type", "author_id", "version", "price", "currency").
 		Values(
 			bid.Name,
 			bid.Description,
@@ -29,6 +39,8 @@ func (r *BidRepository) CreateBid(ctx context.Context, bid entity.Bid) (entity.B
 			bid.AuthorType,
 			bid.AuthorId,
 			bid.Version,
+			bid.Price,
+			bid.Currency,
 		).
 		Suffix("RETURNING id, created_at").
 		ToSql()
@@ -37,34 +49,74 @@ func (r *BidRepository) CreateBid(ctx context.Context, bid entity.Bid) (entity.B
 		return entity.Bid{}, fmt.Errorf("BidRepository.CreateBid  - r.db.Builder.ToSql: %v", err)
 	}
 
-	err = r.db.Pool.QueryRow(ctx, query, args...).Scan(
+	err = tx.QueryRow(ctx, query, args...).Scan(
 		&bid.Id,
 		&bid.CreatedAt,
 	)
 	if err != nil {
-		return entity.Bid{}, fmt.Errorf("BidRepository.CreateBid  - r.db.Pool.QueryRow: %v", err)
+		return entity.Bid{}, fmt.Errorf("BidRepository.CreateBid  - tx.QueryRow: %v", err)
+	}
+
+	if err := insertEvents(ctx, r.db, tx, bidEvent(entity.EventBidCreated, bid, "")); err != nil {
+		return entity.Bid{}, fmt.Errorf("BidRepository.CreateBid - %v", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("BidRepository.CreateBid - tx.Commit: %v", err)
 	}
 
 	return bid, nil
 }
 
-func (r *BidRepository) GetBidsByUserId(ctx context.Context, limit int, offset int, userId string) ([]entity.Bid, error) {
+func (r *BidRepository) GetBidsByUserId(ctx context.Context, page entity.PageRequest, userId string) (entity.Page[entity.Bid], error) {
+	ctx, span := tracer.Start(ctx, "BidRepository.GetBidsByUserId")
+	defer span.End()
+
+	where := squirrel.Expr("author_id = ?", userId)
+	return r.getBids(ctx, "BidRepository.GetBidsByUserId", page, where, bidsKeyset(entity.BidFilter{}))
+}
+
+func (r *BidRepository) GetBidsForTender(ctx context.Context, tenderId string, page entity.PageRequest, filter entity.BidFilter) (entity.Page[entity.Bid], error) {
+	ctx, span := tracer.Start(ctx, "BidRepository.GetBidsForTender")
+	defer span.End()
+
+	where := squirrel.And{
+		squirrel.Expr("tender_id = ?", tenderId),
+		squirrel.Expr("status = ANY(?)", []string{"Published", "Canceled"}),
+	}
+	if filter.MinPrice != nil {
+		where = append(where, squirrel.Expr("price >= ?", filter.MinPrice))
+	}
+	if filter.MaxPrice != nil {
+		where = append(where, squirrel.Expr("price <= ?", filter.MaxPrice))
+	}
+	if filter.LotId != "" {
+		where = append(where, squirrel.Expr("id IN (SELECT bid_id FROM bid_lots WHERE lot_id = ?)", filter.LotId))
+	}
+
+	return r.getBids(ctx, "BidRepository.GetBidsForTender", page, where, bidsKeyset(filter))
+}
+
+func (r *BidRepository) GetPublishedBidsForTender(ctx context.Context, tenderId string) ([]entity.Bid, error) {
+	ctx, span := tracer.Start(ctx, "BidRepository.GetPublishedBidsForTender")
+	defer span.End()
+
 	query, args, err := r.db.Builder.
-		Select("id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "created_at").
+		Select("id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "price", "currency", "created_at").
 		From("bids").
-		Where("author_id = ?", userId).
-		OrderBy("name ASC").
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
+		Where("tender_id = ?", tenderId).
+		Where("status = ?", "Published").
+		OrderBy("created_at ASC").
 		ToSql()
 
 	if err != nil {
-		return nil, fmt.Errorf("BidRepository.GetBidsByUserId  - r.db.Builder.ToSql: %v", err)
+		return nil, fmt.Errorf("BidRepository.GetPublishedBidsForTender  - r.db.Builder.ToSql: %v", err)
 	}
 
-	rows, err := r.db.Pool.Query(ctx, query, args...)
+	rows, err := r.db.Conn().Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("BidRepository.GetBidsByUserId - r.db.Pool.Query: %v", err)
+		return nil, fmt.Errorf("BidRepository.GetPublishedBidsForTender - r.db.Conn().Query: %v", err)
 	}
 	defer rows.Close()
 
@@ -80,9 +132,11 @@ func (r *BidRepository) GetBidsByUserId(ctx context.Context, limit int, offset i
 			&bid.AuthorType,
 			&bid.AuthorId,
 			&bid.Version,
+			&bid.Price,
+			&bid.Currency,
 			&bid.CreatedAt)
 		if err != nil {
-			return nil, fmt.Errorf("BidRepository.GetBidsByUserId - rows.Scan: %v", err)
+			return nil, fmt.Errorf("BidRepository.GetPublishedBidsForTender - rows.Scan: %v", err)
 		}
 		bids = append(bids, bid)
 	}
@@ -90,24 +144,25 @@ func (r *BidRepository) GetBidsByUserId(ctx context.Context, limit int, offset i
 	return bids, nil
 }
 
-func (r *BidRepository) GetBidsForTender(ctx context.Context, tenderId string, limit int, offset int) ([]entity.Bid, error) {
+func (r *BidRepository) GetActiveBidsForTender(ctx context.Context, tenderId string) ([]entity.Bid, error) {
+	ctx, span := tracer.Start(ctx, "BidRepository.GetActiveBidsForTender")
+	defer span.End()
+
 	query, args, err := r.db.Builder.
-		Select("id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "created_at").
+		Select("id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "price", "currency", "created_at").
 		From("bids").
 		Where("tender_id = ?", tenderId).
-		Where("status = ANY(?)", []string{"Published", "Canceled"}).
-		OrderBy("name ASC").
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
+		Where("status <> ?", "Canceled").
+		OrderBy("created_at ASC").
 		ToSql()
 
 	if err != nil {
-		return nil, fmt.Errorf("BidRepository.GetBidsForTender  - r.db.Builder.ToSql: %v", err)
+		return nil, fmt.Errorf("BidRepository.GetActiveBidsForTender  - r.db.Builder.ToSql: %v", err)
 	}
 
-	rows, err := r.db.Pool.Query(ctx, query, args...)
+	rows, err := r.db.Conn().Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("BidRepository.GetBidsForTender - r.db.Pool.Query: %v", err)
+		return nil, fmt.Errorf("BidRepository.GetActiveBidsForTender - r.db.Conn().Query: %v", err)
 	}
 	defer rows.Close()
 
@@ -123,9 +178,11 @@ func (r *BidRepository) GetBidsForTender(ctx context.Context, tenderId string, l
 			&bid.AuthorType,
 			&bid.AuthorId,
 			&bid.Version,
+			&bid.Price,
+			&bid.Currency,
 			&bid.CreatedAt)
 		if err != nil {
-			return nil, fmt.Errorf("BidRepository.GetBidsForTender - rows.Scan: %v", err)
+			return nil, fmt.Errorf("BidRepository.GetActiveBidsForTender - rows.Scan: %v", err)
 		}
 		bids = append(bids, bid)
 	}
@@ -133,9 +190,40 @@ func (r *BidRepository) GetBidsForTender(ctx context.Context, tenderId string, l
 	return bids, nil
 }
 
+func (r *BidRepository) CountBidsForTender(ctx context.Context, tenderId string, lotId string) (int, error) {
+	ctx, span := tracer.Start(ctx, "BidRepository.CountBidsForTender")
+	defer span.End()
+
+	countBids := r.db.Builder.
+		Select("COUNT(*)").
+		From("bids").
+		Where("tender_id = ?", tenderId).
+		Where("status = ANY(?)", []string{"Published", "Canceled"})
+	if lotId != "" {
+		countBids = countBids.Where("id IN (SELECT bid_id FROM bid_lots WHERE lot_id = ?)", lotId)
+	}
+
+	query, args, err := countBids.ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("BidRepository.CountBidsForTender  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var count int
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("BidRepository.CountBidsForTender  - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return count, nil
+}
+
 func (r *BidRepository) GetBidById(ctx context.Context, id string) (entity.Bid, error) {
+	ctx, span := tracer.Start(ctx, "BidRepository.GetBidById")
+	defer span.End()
+
 	query, args, err := r.db.Builder.
-		Select("id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "created_at").
+		Select("id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "price", "currency", "created_at").
 		From("bids").
 		Where("id = ?", id).
 		ToSql()
@@ -145,7 +233,7 @@ func (r *BidRepository) GetBidById(ctx context.Context, id string) (entity.Bid,
 	}
 
 	var bid entity.Bid
-	err = r.db.Pool.QueryRow(ctx, query, args...).Scan(
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
 		&bid.Id,
 		&bid.Name,
 		&bid.Description,
@@ -154,24 +242,35 @@ func (r *BidRepository) GetBidById(ctx context.Context, id string) (entity.Bid,
 		&bid.AuthorType,
 		&bid.AuthorId,
 		&bid.Version,
+		&bid.Price,
+		&bid.Currency,
 		&bid.CreatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entity.Bid{}, repoerrs.ErrNotFound
 		}
-		return entity.Bid{}, fmt.Errorf("BidRepository.GetBidById  - r.db.Pool.QueryRow: %v", err)
+		return entity.Bid{}, fmt.Errorf("BidRepository.GetBidById  - r.db.Conn().QueryRow: %v", err)
 	}
 
 	return bid, nil
 }
 
 func (r *BidRepository) UpdateBidStatus(ctx context.Context, bidId string, status string) (entity.Bid, error) {
+	ctx, span := tracer.Start(ctx, "BidRepository.UpdateBidStatus")
+	defer span.End()
+
+	tx, err := r.db.Conn().Begin(ctx)
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("BidRepository.UpdateBidStatus - r.Pool.Begin: %v", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
 	query, args, err := r.db.Builder.
 		Update("bids").
 		Set("status", status).
 		Where("id = ?", bidId).
-		Suffix("RETURNING id, name, description, status, tender_id, author_type, author_id, version, created_at").
+		Suffix("RETURNING id, name, description, status, tender_id, author_type, author_id, version, price, currency, created_at").
 		ToSql()
 
 	if err != nil {
@@ -179,7 +278,7 @@ func (r *BidRepository) UpdateBidStatus(ctx context.Context, bidId string, statu
 	}
 
 	var bid entity.Bid
-	err = r.db.Pool.QueryRow(ctx, query, args...).Scan(
+	err = tx.QueryRow(ctx, query, args...).Scan(
 		&bid.Id,
 		&bid.Name,
 		&bid.Description,
@@ -188,27 +287,41 @@ func (r *BidRepository) UpdateBidStatus(ctx context.Context, bidId string, statu
 		&bid.AuthorType,
 		&bid.AuthorId,
 		&bid.Version,
+		&bid.Price,
+		&bid.Currency,
 		&bid.CreatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entity.Bid{}, repoerrs.ErrNotFound
 		}
-		return entity.Bid{}, fmt.Errorf("BidRepository.UpdateBidStatus  - r.db.Pool.QueryRow: %v", err)
+		return entity.Bid{}, fmt.Errorf("BidRepository.UpdateBidStatus  - tx.QueryRow: %v", err)
+	}
+
+	if err := insertEvents(ctx, r.db, tx, bidEvent(entity.EventBidEdited, bid, "")); err != nil {
+		return entity.Bid{}, fmt.Errorf("BidRepository.UpdateBidStatus - %v", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("BidRepository.UpdateBidStatus - tx.Commit: %v", err)
 	}
 
 	return bid, nil
 }
 
 func (r *BidRepository) UpdateBid(ctx context.Context, bidId string, input entity.EditBidInput) (entity.Bid, error) {
-	tx, err := r.db.Pool.Begin(ctx)
+	ctx, span := tracer.Start(ctx, "BidRepository.UpdateBid")
+	defer span.End()
+
+	tx, err := r.db.Conn().Begin(ctx)
 	if err != nil {
 		return entity.Bid{}, fmt.Errorf("BidRepository.UpdateBid - r.Pool.Begin: %v", err)
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
 	getBid, args, err := r.db.Builder.
-		Select("id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "created_at").
+		Select("id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "price", "currency", "created_at").
 		From("bids").
 		Where("id = ?", bidId).
 		ToSql()
@@ -227,6 +340,8 @@ func (r *BidRepository) UpdateBid(ctx context.Context, bidId string, input entit
 		&bid.AuthorType,
 		&bid.AuthorId,
 		&bid.Version,
+		&bid.Price,
+		&bid.Currency,
 		&bid.CreatedAt,
 	)
 	if err != nil {
@@ -235,19 +350,24 @@ func (r *BidRepository) UpdateBid(ctx context.Context, bidId string, input entit
 		}
 		return entity.Bid{}, fmt.Errorf("BidRepository.UpdateBid  - tx.QueryRow: %v", err)
 	}
+	if input.Version != nil && *input.Version != bid.Version {
+		return entity.Bid{}, repoerrs.ErrConflict
+	}
 
 	createOldVersionBid, args, err := r.db.Builder.
 		Insert("bids_old_version").
-		Columns("bid_id", "name", "description", "status", "tender_id", "author_type", "author_id", "version").
+		Columns("bid_id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "price", "currency").
 		Values(
-			&bid.Id,
-			&bid.Name,
-			&bid.Description,
-			&bid.Status,
-			&bid.TenderId,
-			&bid.AuthorType,
-			&bid.AuthorId,
-			&bid.Version,
+			bid.Id,
+			bid.Name,
+			bid.Description,
+			bid.Status,
+			bid.TenderId,
+			bid.AuthorType,
+			bid.AuthorId,
+			bid.Version,
+			bid.Price,
+			bid.Currency,
 		).
 		ToSql()
 
@@ -262,7 +382,8 @@ func (r *BidRepository) UpdateBid(ctx context.Context, bidId string, input entit
 
 	updateBidQuery := r.db.Builder.
 		Update("bids").
-		Where("id = ?", bidId)
+		Where("id = ?", bidId).
+		Where("version = ?", bid.Version)
 	bid.Version += 1
 	updateBidQuery = updateBidQuery.Set("version", bid.Version)
 	if input.Name != nil {
@@ -273,16 +394,31 @@ func (r *BidRepository) UpdateBid(ctx context.Context, bidId string, input entit
 		bid.Description = *input.Description
 		updateBidQuery = updateBidQuery.Set("description", bid.Description)
 	}
+	if input.Price != nil {
+		bid.Price = input.Price
+		updateBidQuery = updateBidQuery.Set("price", bid.Price)
+	}
+	if input.Currency != nil {
+		bid.Currency = input.Currency
+		updateBidQuery = updateBidQuery.Set("currency", bid.Currency)
+	}
 	updateBid, args, err := updateBidQuery.ToSql()
 
 	if err != nil {
 		return entity.Bid{}, fmt.Errorf("BidRepository.UpdateBid  - r.db.Builder.ToSql: %v", err)
 	}
 
-	_, err = tx.Exec(ctx, updateBid, args...)
+	tag, err := tx.Exec(ctx, updateBid, args...)
 	if err != nil {
 		return entity.Bid{}, fmt.Errorf("BidRepository.UpdateBid - tx.Exec: %v", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return entity.Bid{}, repoerrs.ErrConflict
+	}
+
+	if err := insertEvents(ctx, r.db, tx, bidEvent(entity.EventBidEdited, bid, "")); err != nil {
+		return entity.Bid{}, fmt.Errorf("BidRepository.UpdateBid - %v", err)
+	}
 
 	err = tx.Commit(ctx)
 	if err != nil {
@@ -293,14 +429,17 @@ func (r *BidRepository) UpdateBid(ctx context.Context, bidId string, input entit
 }
 
 func (r *BidRepository) RollbackBid(ctx context.Context, bidId string, version int) (entity.Bid, error) {
-	tx, err := r.db.Pool.Begin(ctx)
+	ctx, span := tracer.Start(ctx, "BidRepository.RollbackBid")
+	defer span.End()
+
+	tx, err := r.db.Conn().Begin(ctx)
 	if err != nil {
 		return entity.Bid{}, fmt.Errorf("BidRepository.RollbackBid - r.Pool.Begin: %v", err)
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
 	getCurrentBid, args, err := r.db.Builder.
-		Select("id", "name", "description", "status", "tender_id", "author_type", "author_id", "version").
+		Select("id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "price", "currency").
 		From("bids").
 		Where("id = ?", bidId).
 		ToSql()
@@ -318,7 +457,9 @@ func (r *BidRepository) RollbackBid(ctx context.Context, bidId string, version i
 		&currentBid.TenderId,
 		&currentBid.AuthorType,
 		&currentBid.AuthorId,
-		&currentBid, version,
+		&currentBid.Version,
+		&currentBid.Price,
+		&currentBid.Currency,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -329,7 +470,7 @@ func (r *BidRepository) RollbackBid(ctx context.Context, bidId string, version i
 
 	createOldVersionBid, args, err := r.db.Builder.
 		Insert("bids_old_version").
-		Columns("bid_id", "name", "description", "status", "tender_id", "author_type", "author_id", "version").
+		Columns("bid_id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "price", "currency").
 		Values(
 			currentBid.Id,
 			currentBid.Name,
@@ -339,6 +480,8 @@ func (r *BidRepository) RollbackBid(ctx context.Context, bidId string, version i
 			currentBid.AuthorType,
 			currentBid.AuthorId,
 			currentBid.Version,
+			currentBid.Price,
+			currentBid.Currency,
 		).
 		ToSql()
 
@@ -352,7 +495,7 @@ func (r *BidRepository) RollbackBid(ctx context.Context, bidId string, version i
 	}
 
 	getBidReqVersion, args, err := r.db.Builder.
-		Select("bid_id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "created_at").
+		Select("bid_id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "price", "currency", "created_at").
 		From("bids_old_version").
 		Where("bid_id = ?", bidId).
 		Where("version = ?", version).
@@ -372,6 +515,8 @@ func (r *BidRepository) RollbackBid(ctx context.Context, bidId string, version i
 		&bidReqVersion.AuthorType,
 		&bidReqVersion.AuthorId,
 		&bidReqVersion.Version,
+		&bidReqVersion.Price,
+		&bidReqVersion.Currency,
 		&bidReqVersion.CreatedAt,
 	)
 	if err != nil {
@@ -384,6 +529,8 @@ func (r *BidRepository) RollbackBid(ctx context.Context, bidId string, version i
 	currentBid.Version += 1
 	currentBid.Name = bidReqVersion.Name
 	currentBid.Description = bidReqVersion.Description
+	currentBid.Price = bidReqVersion.Price
+	currentBid.Currency = bidReqVersion.Currency
 
 	rollbackBidReqVersion, args, err := r.db.Builder.
 		Update("bids").
@@ -391,31 +538,46 @@ func (r *BidRepository) RollbackBid(ctx context.Context, bidId string, version i
 		Set("version", currentBid.Version).
 		Set("name", currentBid.Name).
 		Set("description", currentBid.Description).
+		Set("price", currentBid.Price).
+		Set("currency", currentBid.Currency).
 		ToSql()
 
 	if err != nil {
-		return entity.Bid{}, fmt.Errorf("BidRepository.RollbackTender  - r.db.Builder.ToSql: %v", err)
+		return entity.Bid{}, fmt.Errorf("BidRepository.RollbackBid  - r.db.Builder.ToSql: %v", err)
 	}
 
 	_, err = tx.Exec(ctx, rollbackBidReqVersion, args...)
 	if err != nil {
-		return entity.Bid{}, fmt.Errorf("BidRepository.RollbackTender - tx.Exec: %v", err)
+		return entity.Bid{}, fmt.Errorf("BidRepository.RollbackBid - tx.Exec: %v", err)
+	}
+
+	if err := insertEvents(ctx, r.db, tx, bidEvent(entity.EventBidEdited, currentBid, "")); err != nil {
+		return entity.Bid{}, fmt.Errorf("BidRepository.RollbackBid - %v", err)
 	}
 
 	err = tx.Commit(ctx)
 	if err != nil {
-		return entity.Bid{}, fmt.Errorf("BidRepository.RollbackTender - tx.Commit: %v", err)
+		return entity.Bid{}, fmt.Errorf("BidRepository.RollbackBid - tx.Commit: %v", err)
 	}
 
 	return currentBid, nil
 }
 
 func (r *BidRepository) SubmitBidDecision(ctx context.Context, bidId string, decision string) (entity.Bid, error) {
+	ctx, span := tracer.Start(ctx, "BidRepository.SubmitBidDecision")
+	defer span.End()
+
+	tx, err := r.db.Conn().Begin(ctx)
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("BidRepository.SubmitBidDecision - r.Pool.Begin: %v", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
 	query, args, err := r.db.Builder.
 		Update("bids").
 		Where("id = ?", bidId).
 		Set("decision", decision).
-		Suffix("RETURNING id, name, description, status, tender_id, author_type, author_id, version, created_at").
+		Suffix("RETURNING id, name, description, status, tender_id, author_type, author_id, version, price, currency, created_at").
 		ToSql()
 
 	if err != nil {
@@ -423,7 +585,178 @@ func (r *BidRepository) SubmitBidDecision(ctx context.Context, bidId string, dec
 	}
 
 	var bid entity.Bid
-	err = r.db.Pool.QueryRow(ctx, query, args...).Scan(
+	err = tx.QueryRow(ctx, query, args...).Scan(
+		&bid.Id,
+		&bid.Name,
+		&bid.Description,
+		&bid.Status,
+		&bid.TenderId,
+		&bid.AuthorType,
+		&bid.AuthorId,
+		&bid.Version,
+		&bid.Price,
+		&bid.Currency,
+		&bid.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Bid{}, repoerrs.ErrNotFound
+		}
+		return entity.Bid{}, fmt.Errorf("BidRepository.SubmitBidDecision  - tx.QueryRow: %v", err)
+	}
+
+	if err := insertEvents(ctx, r.db, tx, bidEvent(entity.EventBidDecided, bid, decision)); err != nil {
+		return entity.Bid{}, fmt.Errorf("BidRepository.SubmitBidDecision - %v", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("BidRepository.SubmitBidDecision - tx.Commit: %v", err)
+	}
+
+	return bid, nil
+}
+
+func (r *BidRepository) CreateBidDecision(ctx context.Context, decision entity.BidDecision) (entity.BidDecision, error) {
+	ctx, span := tracer.Start(ctx, "BidRepository.CreateBidDecision")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Insert("bid_decisions").
+		Columns("bid_id", "lot_id", "user_id", "decision").
+		Values(
+			decision.BidId,
+			decision.LotId,
+			decision.UserId,
+			decision.Decision,
+		).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	if err != nil {
+		return entity.BidDecision{}, fmt.Errorf("BidRepository.CreateBidDecision  - r.db.Builder.ToSql: %v", err)
+	}
+
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&decision.Id,
+		&decision.CreatedAt,
+	)
+	if err != nil {
+		if isPgError(err, uniqueViolationCode) {
+			return entity.BidDecision{}, repoerrs.ErrAlreadyExists
+		}
+		return entity.BidDecision{}, fmt.Errorf("BidRepository.CreateBidDecision  - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return decision, nil
+}
+
+func (r *BidRepository) GetBidDecisions(ctx context.Context, bidId string) ([]entity.BidDecision, error) {
+	ctx, span := tracer.Start(ctx, "BidRepository.GetBidDecisions")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id", "bid_id", "lot_id", "user_id", "decision", "created_at").
+		From("bid_decisions").
+		Where("bid_id = ?", bidId).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("BidRepository.GetBidDecisions  - r.db.Builder.ToSql: %v", err)
+	}
+
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("BidRepository.GetBidDecisions - r.db.Conn().Query: %v", err)
+	}
+	defer rows.Close()
+
+	var decisions []entity.BidDecision
+	for rows.Next() {
+		var decision entity.BidDecision
+		err := rows.Scan(
+			&decision.Id,
+			&decision.BidId,
+			&decision.LotId,
+			&decision.UserId,
+			&decision.Decision,
+			&decision.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("BidRepository.GetBidDecisions - rows.Scan: %v", err)
+		}
+		decisions = append(decisions, decision)
+	}
+
+	return decisions, nil
+}
+
+func (r *BidRepository) GetBidVersions(ctx context.Context, bidId string) ([]entity.Bid, error) {
+	ctx, span := tracer.Start(ctx, "BidRepository.GetBidVersions")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("bid_id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "price", "currency", "created_at").
+		Options("DISTINCT ON (version)").
+		From("bids_old_version").
+		Where("bid_id = ?", bidId).
+		OrderBy("version ASC", "created_at DESC").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("BidRepository.GetBidVersions  - r.db.Builder.ToSql: %v", err)
+	}
+
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("BidRepository.GetBidVersions - r.db.Conn().Query: %v", err)
+	}
+	defer rows.Close()
+
+	var bids []entity.Bid
+	for rows.Next() {
+		var bid entity.Bid
+		err := rows.Scan(
+			&bid.Id,
+			&bid.Name,
+			&bid.Description,
+			&bid.Status,
+			&bid.TenderId,
+			&bid.AuthorType,
+			&bid.AuthorId,
+			&bid.Version,
+			&bid.Price,
+			&bid.Currency,
+			&bid.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("BidRepository.GetBidVersions - rows.Scan: %v", err)
+		}
+		bids = append(bids, bid)
+	}
+
+	return bids, nil
+}
+
+func (r *BidRepository) GetBidVersion(ctx context.Context, bidId string, version int) (entity.Bid, error) {
+	ctx, span := tracer.Start(ctx, "BidRepository.GetBidVersion")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("bid_id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "price", "currency", "created_at").
+		From("bids_old_version").
+		Where("bid_id = ?", bidId).
+		Where("version = ?", version).
+		OrderBy("created_at DESC").
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("BidRepository.GetBidVersion  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var bid entity.Bid
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
 		&bid.Id,
 		&bid.Name,
 		&bid.Description,
@@ -432,14 +765,100 @@ func (r *BidRepository) SubmitBidDecision(ctx context.Context, bidId string, dec
 		&bid.AuthorType,
 		&bid.AuthorId,
 		&bid.Version,
+		&bid.Price,
+		&bid.Currency,
 		&bid.CreatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entity.Bid{}, repoerrs.ErrNotFound
 		}
-		return entity.Bid{}, fmt.Errorf("BidRepository.SubmitBidDecision  - r.db.Pool.QueryRow: %v", err)
+		return entity.Bid{}, fmt.Errorf("BidRepository.GetBidVersion  - r.db.Conn().QueryRow: %v", err)
 	}
 
 	return bid, nil
 }
+
+func (r *BidRepository) getBids(ctx context.Context, op string, page entity.PageRequest, where squirrel.Sqlizer, k keyset) (entity.Page[entity.Bid], error) {
+	getBids, err := k.paginate(r.db.Builder.
+		Select("id", "name", "description", "status", "tender_id", "author_type", "author_id", "version", "price", "currency", "created_at").
+		From("bids").
+		Where(where), page)
+	if err != nil {
+		return entity.Page[entity.Bid]{}, err
+	}
+
+	query, args, err := getBids.ToSql()
+	if err != nil {
+		return entity.Page[entity.Bid]{}, fmt.Errorf("%s  - getBids.ToSql: %v", op, err)
+	}
+
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return entity.Page[entity.Bid]{}, fmt.Errorf("%s - r.db.Conn().Query: %v", op, err)
+	}
+	defer rows.Close()
+
+	var bids []entity.Bid
+	var keys []string
+	for rows.Next() {
+		var bid entity.Bid
+		var key string
+		err := rows.Scan(
+			&bid.Id,
+			&bid.Name,
+			&bid.Description,
+			&bid.Status,
+			&bid.TenderId,
+			&bid.AuthorType,
+			&bid.AuthorId,
+			&bid.Version,
+			&bid.Price,
+			&bid.Currency,
+			&bid.CreatedAt,
+			&key,
+		)
+		if err != nil {
+			return entity.Page[entity.Bid]{}, fmt.Errorf("%s - rows.Scan: %v", op, err)
+		}
+		bids = append(bids, bid)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return entity.Page[entity.Bid]{}, fmt.Errorf("%s - rows.Err: %v", op, err)
+	}
+
+	result := pageOf(k, bids, keys, func(bid entity.Bid) string { return bid.Id }, page.Limit)
+	if page.Cursor == nil {
+		return result, nil
+	}
+
+	count, args, err := r.db.Builder.
+		Select("COUNT(*)").
+		From("bids").
+		Where(where).
+		ToSql()
+
+	if err != nil {
+		return entity.Page[entity.Bid]{}, fmt.Errorf("%s  - r.db.Builder.ToSql: %v", op, err)
+	}
+
+	err = r.db.Conn().QueryRow(ctx, count, args...).Scan(&result.Total)
+	if err != nil {
+		return entity.Page[entity.Bid]{}, fmt.Errorf("%s - r.db.Conn().QueryRow: %v", op, err)
+	}
+
+	return result, nil
+}
+
+func bidsKeyset(filter entity.BidFilter) keyset {
+	k := keyset{column: "name", cast: "text", desc: filter.SortOrder == "desc"}
+	switch filter.SortBy {
+	case "price":
+		k.column, k.cast = "COALESCE(price, 10000000000000000)", "numeric"
+	case "created_at":
+		k.column, k.cast = "created_at", "timestamp"
+	}
+	k.sort = "bids:" + k.column + ":" + filter.SortOrder
+	return k
+}
@@ -0,0 +1,262 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"tender-service/internal/entity"
+	"tender-service/internal/repository/repoerrs"
+	"time"
+)
+
+func (r *AuthRepository) CreateEmployee(ctx context.Context, employee entity.Employee, passwordHash string) (entity.Employee, error) {
+	ctx, span := tracer.Start(ctx, "AuthRepository.CreateEmployee")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Insert("employee").
+		Columns("username", "first_name", "last_name", "password_hash").
+		Values(
+			employee.Username,
+			employee.FirstName,
+			employee.LastName,
+			passwordHash,
+		).
+		Suffix("RETURNING id, created_at, updated_at").
+		ToSql()
+
+	if err != nil {
+		return entity.Employee{}, fmt.Errorf("AuthRepository.CreateEmployee  - r.db.Builder.ToSql: %v", err)
+	}
+
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&employee.Id,
+		&employee.CreatedAt,
+		&employee.UpdatedAt,
+	)
+	if err != nil {
+		if isPgError(err, uniqueViolationCode) {
+			return entity.Employee{}, repoerrs.ErrAlreadyExists
+		}
+		return entity.Employee{}, fmt.Errorf("AuthRepository.CreateEmployee  - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return employee, nil
+}
+
+func (r *AuthRepository) GetEmployees(ctx context.Context, limit int, offset int) ([]entity.Employee, error) {
+	ctx, span := tracer.Start(ctx, "AuthRepository.GetEmployees")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id", "username", "COALESCE(first_name, '')", "COALESCE(last_name, '')", "created_at", "updated_at").
+		From("employee").
+		OrderBy("username ASC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("AuthRepository.GetEmployees  - r.db.Builder.ToSql: %v", err)
+	}
+
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("AuthRepository.GetEmployees - r.db.Conn().Query: %v", err)
+	}
+	defer rows.Close()
+
+	var employees []entity.Employee
+	for rows.Next() {
+		var employee entity.Employee
+		err := rows.Scan(
+			&employee.Id,
+			&employee.Username,
+			&employee.FirstName,
+			&employee.LastName,
+			&employee.CreatedAt,
+			&employee.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("AuthRepository.GetEmployees - rows.Scan: %v", err)
+		}
+		employees = append(employees, employee)
+	}
+
+	return employees, nil
+}
+
+func (r *AuthRepository) GetEmployeeById(ctx context.Context, id string) (entity.Employee, error) {
+	ctx, span := tracer.Start(ctx, "AuthRepository.GetEmployeeById")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id", "username", "COALESCE(first_name, '')", "COALESCE(last_name, '')", "created_at", "updated_at").
+		From("employee").
+		Where("id = ?", id).
+		ToSql()
+
+	if err != nil {
+		return entity.Employee{}, fmt.Errorf("AuthRepository.GetEmployeeById  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var employee entity.Employee
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&employee.Id,
+		&employee.Username,
+		&employee.FirstName,
+		&employee.LastName,
+		&employee.CreatedAt,
+		&employee.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Employee{}, repoerrs.ErrNotFound
+		}
+		return entity.Employee{}, fmt.Errorf("AuthRepository.GetEmployeeById  - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return employee, nil
+}
+
+func (r *AuthRepository) UpdateEmployee(ctx context.Context, id string, input entity.EditEmployeeInput, passwordHash *string) (entity.Employee, error) {
+	ctx, span := tracer.Start(ctx, "AuthRepository.UpdateEmployee")
+	defer span.End()
+
+	updateEmployeeQuery := r.db.Builder.
+		Update("employee").
+		Set("updated_at", currentTimestamp).
+		Where("id = ?", id)
+	if input.FirstName != nil {
+		updateEmployeeQuery = updateEmployeeQuery.Set("first_name", *input.FirstName)
+	}
+	if input.LastName != nil {
+		updateEmployeeQuery = updateEmployeeQuery.Set("last_name", *input.LastName)
+	}
+	if passwordHash != nil {
+		updateEmployeeQuery = updateEmployeeQuery.Set("password_hash", *passwordHash)
+	}
+
+	query, args, err := updateEmployeeQuery.
+		Suffix("RETURNING id, username, COALESCE(first_name, ''), COALESCE(last_name, ''), created_at, updated_at").
+		ToSql()
+
+	if err != nil {
+		return entity.Employee{}, fmt.Errorf("AuthRepository.UpdateEmployee  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var employee entity.Employee
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&employee.Id,
+		&employee.Username,
+		&employee.FirstName,
+		&employee.LastName,
+		&employee.CreatedAt,
+		&employee.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Employee{}, repoerrs.ErrNotFound
+		}
+		return entity.Employee{}, fmt.Errorf("AuthRepository.UpdateEmployee  - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return employee, nil
+}
+
+func (r *AuthRepository) DeleteEmployee(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "AuthRepository.DeleteEmployee")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Delete("employee").
+		Where("id = ?", id).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("AuthRepository.DeleteEmployee  - r.db.Builder.ToSql: %v", err)
+	}
+
+	tag, err := r.db.Conn().Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("AuthRepository.DeleteEmployee - r.db.Conn().Exec: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *AuthRepository) CreatePasswordReset(ctx context.Context, employeeId string, tokenHash string, createdBy *string, expiresAt time.Time) error {
+	ctx, span := tracer.Start(ctx, "AuthRepository.CreatePasswordReset")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Insert("password_reset").
+		Columns("token_hash", "employee_id", "created_by", "expires_at").
+		Values(tokenHash, employeeId, createdBy, expiresAt).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("AuthRepository.CreatePasswordReset  - r.db.Builder.ToSql: %v", err)
+	}
+
+	_, err = r.db.Conn().Exec(ctx, query, args...)
+	if err != nil {
+		if isPgError(err, foreignKeyViolationCode) {
+			return repoerrs.ErrNotFound
+		}
+		return fmt.Errorf("AuthRepository.CreatePasswordReset - r.db.Conn().Exec: %v", err)
+	}
+
+	return nil
+}
+
+// ConsumePasswordReset marks an unused, unexpired token as used together with every other pending token of the
+// same employee and returns the employee id.
+func (r *AuthRepository) ConsumePasswordReset(ctx context.Context, tokenHash string) (string, error) {
+	ctx, span := tracer.Start(ctx, "AuthRepository.ConsumePasswordReset")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Update("password_reset").
+		Set("used_at", currentTimestamp).
+		Where("token_hash = ?", tokenHash).
+		Where("used_at IS NULL").
+		Where("expires_at > CURRENT_TIMESTAMP").
+		Suffix("RETURNING employee_id").
+		ToSql()
+
+	if err != nil {
+		return "", fmt.Errorf("AuthRepository.ConsumePasswordReset  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var employeeId string
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(&employeeId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", repoerrs.ErrNotFound
+		}
+		return "", fmt.Errorf("AuthRepository.ConsumePasswordReset  - r.db.Conn().QueryRow: %v", err)
+	}
+
+	query, args, err = r.db.Builder.
+		Update("password_reset").
+		Set("used_at", currentTimestamp).
+		Where("employee_id = ?", employeeId).
+		Where("used_at IS NULL").
+		ToSql()
+
+	if err != nil {
+		return "", fmt.Errorf("AuthRepository.ConsumePasswordReset  - r.db.Builder.ToSql: %v", err)
+	}
+
+	_, err = r.db.Conn().Exec(ctx, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("AuthRepository.ConsumePasswordReset - r.db.Conn().Exec: %v", err)
+	}
+
+	return employeeId, nil
+}
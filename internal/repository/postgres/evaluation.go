@@ -0,0 +1,310 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"tender-service/internal/entity"
+	"tender-service/internal/repository/repoerrs"
+)
+
+type EvaluationRepository struct {
+	db *Postgres
+}
+
+func NewEvaluationRepository(db *Postgres) *EvaluationRepository {
+	return &EvaluationRepository{db: db}
+}
+
+func (r *EvaluationRepository) GetCriteria(ctx context.Context, tenderId string) ([]entity.Criterion, error) {
+	ctx, span := tracer.Start(ctx, "EvaluationRepository.GetCriteria")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id", "tender_id", "name", "description", "weight", "max_score", "created_at").
+		From("tender_criteria").
+		Where("tender_id = ?", tenderId).
+		OrderBy("weight DESC", "name ASC").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("EvaluationRepository.GetCriteria  - r.db.Builder.ToSql: %v", err)
+	}
+
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("EvaluationRepository.GetCriteria - r.db.Conn().Query: %v", err)
+	}
+	defer rows.Close()
+
+	var criteria []entity.Criterion
+	for rows.Next() {
+		var criterion entity.Criterion
+		err := rows.Scan(
+			&criterion.Id,
+			&criterion.TenderId,
+			&criterion.Name,
+			&criterion.Description,
+			&criterion.Weight,
+			&criterion.MaxScore,
+			&criterion.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("EvaluationRepository.GetCriteria - rows.Scan: %v", err)
+		}
+		criteria = append(criteria, criterion)
+	}
+
+	return criteria, nil
+}
+
+func (r *EvaluationRepository) ReplaceCriteria(ctx context.Context, tenderId string, criteria []entity.Criterion) ([]entity.Criterion, error) {
+	ctx, span := tracer.Start(ctx, "EvaluationRepository.ReplaceCriteria")
+	defer span.End()
+
+	deleteCriteria, args, err := r.db.Builder.
+		Delete("tender_criteria").
+		Where("tender_id = ?", tenderId).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("EvaluationRepository.ReplaceCriteria  - r.db.Builder.ToSql: %v", err)
+	}
+
+	_, err = r.db.Conn().Exec(ctx, deleteCriteria, args...)
+	if err != nil {
+		return nil, fmt.Errorf("EvaluationRepository.ReplaceCriteria - r.db.Conn().Exec: %v", err)
+	}
+
+	for i, criterion := range criteria {
+		createCriterion, args, err := r.db.Builder.
+			Insert("tender_criteria").
+			Columns("tender_id", "name", "description", "weight", "max_score").
+			Values(
+				tenderId,
+				criterion.Name,
+				criterion.Description,
+				criterion.Weight,
+				criterion.MaxScore,
+			).
+			Suffix("RETURNING id, created_at").
+			ToSql()
+
+		if err != nil {
+			return nil, fmt.Errorf("EvaluationRepository.ReplaceCriteria  - r.db.Builder.ToSql: %v", err)
+		}
+
+		err = r.db.Conn().QueryRow(ctx, createCriterion, args...).Scan(
+			&criteria[i].Id,
+			&criteria[i].CreatedAt,
+		)
+		if err != nil {
+			if isPgError(err, uniqueViolationCode) {
+				return nil, repoerrs.ErrAlreadyExists
+			}
+			return nil, fmt.Errorf("EvaluationRepository.ReplaceCriteria - r.db.Conn().QueryRow: %v", err)
+		}
+		criteria[i].TenderId = tenderId
+	}
+
+	return criteria, nil
+}
+
+func (r *EvaluationRepository) TenderHasScores(ctx context.Context, tenderId string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "EvaluationRepository.TenderHasScores")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("s.id").
+		From("bid_scores s").
+		Join("tender_criteria c ON c.id = s.criterion_id").
+		Where("c.tender_id = ?", tenderId).
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("EvaluationRepository.TenderHasScores  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var id string
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("EvaluationRepository.TenderHasScores - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return true, nil
+}
+
+func (r *EvaluationRepository) SaveBidScore(ctx context.Context, score entity.BidScore) (entity.BidScore, error) {
+	ctx, span := tracer.Start(ctx, "EvaluationRepository.SaveBidScore")
+	defer span.End()
+
+	createScore, args, err := r.db.Builder.
+		Insert("bid_scores").
+		Columns("bid_id", "criterion_id", "user_id", "score", "comment", "bid_version").
+		Values(
+			score.BidId,
+			score.CriterionId,
+			score.UserId,
+			score.Score,
+			score.Comment,
+			score.BidVersion,
+		).
+		Suffix("ON CONFLICT (bid_id, criterion_id, user_id) DO NOTHING RETURNING id, version, created_at").
+		ToSql()
+
+	if err != nil {
+		return entity.BidScore{}, fmt.Errorf("EvaluationRepository.SaveBidScore  - r.db.Builder.ToSql: %v", err)
+	}
+
+	err = r.db.Conn().QueryRow(ctx, createScore, args...).Scan(
+		&score.Id,
+		&score.Version,
+		&score.CreatedAt,
+	)
+	if err == nil {
+		return score, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return entity.BidScore{}, fmt.Errorf("EvaluationRepository.SaveBidScore - r.db.Conn().QueryRow: %v", err)
+	}
+
+	createOldVersionScore, args, err := r.db.Builder.
+		Insert("bid_scores_old_version").
+		Columns("score_id", "bid_id", "criterion_id", "user_id", "score", "comment", "bid_version", "version").
+		Select(r.db.Builder.
+			Select("id", "bid_id", "criterion_id", "user_id", "score", "comment", "bid_version", "version").
+			From("bid_scores").
+			Where("bid_id = ?", score.BidId).
+			Where("criterion_id = ?", score.CriterionId).
+			Where("user_id = ?", score.UserId).
+			Suffix("FOR UPDATE")).
+		ToSql()
+
+	if err != nil {
+		return entity.BidScore{}, fmt.Errorf("EvaluationRepository.SaveBidScore  - r.db.Builder.ToSql: %v", err)
+	}
+
+	_, err = r.db.Conn().Exec(ctx, createOldVersionScore, args...)
+	if err != nil {
+		return entity.BidScore{}, fmt.Errorf("EvaluationRepository.SaveBidScore - r.db.Conn().Exec: %v", err)
+	}
+
+	updateScore, args, err := r.db.Builder.
+		Update("bid_scores").
+		Set("score", score.Score).
+		Set("comment", score.Comment).
+		Set("bid_version", score.BidVersion).
+		Set("version", squirrel.Expr("version + 1")).
+		Set("updated_at", currentTimestamp).
+		Where("bid_id = ?", score.BidId).
+		Where("criterion_id = ?", score.CriterionId).
+		Where("user_id = ?", score.UserId).
+		Suffix("RETURNING id, version, created_at").
+		ToSql()
+
+	if err != nil {
+		return entity.BidScore{}, fmt.Errorf("EvaluationRepository.SaveBidScore  - r.db.Builder.ToSql: %v", err)
+	}
+
+	err = r.db.Conn().QueryRow(ctx, updateScore, args...).Scan(
+		&score.Id,
+		&score.Version,
+		&score.CreatedAt,
+	)
+	if err != nil {
+		return entity.BidScore{}, fmt.Errorf("EvaluationRepository.SaveBidScore - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return score, nil
+}
+
+func (r *EvaluationRepository) GetBidScores(ctx context.Context, bidId string) ([]entity.BidScore, error) {
+	ctx, span := tracer.Start(ctx, "EvaluationRepository.GetBidScores")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id", "bid_id", "criterion_id", "user_id", "score", "comment", "bid_version", "version", "created_at").
+		From("bid_scores").
+		Where("bid_id = ?", bidId).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("EvaluationRepository.GetBidScores  - r.db.Builder.ToSql: %v", err)
+	}
+
+	return r.queryScores(ctx, "EvaluationRepository.GetBidScores", query, args)
+}
+
+func (r *EvaluationRepository) GetBidScoreHistory(ctx context.Context, bidId string) ([]entity.BidScore, error) {
+	ctx, span := tracer.Start(ctx, "EvaluationRepository.GetBidScoreHistory")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("score_id", "bid_id", "criterion_id", "user_id", "score", "comment", "bid_version", "version", "created_at").
+		From("bid_scores_old_version").
+		Where("bid_id = ?", bidId).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("EvaluationRepository.GetBidScoreHistory  - r.db.Builder.ToSql: %v", err)
+	}
+
+	return r.queryScores(ctx, "EvaluationRepository.GetBidScoreHistory", query, args)
+}
+
+func (r *EvaluationRepository) GetTenderScores(ctx context.Context, tenderId string) ([]entity.BidScore, error) {
+	ctx, span := tracer.Start(ctx, "EvaluationRepository.GetTenderScores")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("s.id", "s.bid_id", "s.criterion_id", "s.user_id", "s.score", "s.comment", "s.bid_version", "s.version", "s.created_at").
+		From("bid_scores s").
+		Join("bids b ON b.id = s.bid_id").
+		Where("b.tender_id = ?", tenderId).
+		Where("b.status = ?", "Published").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("EvaluationRepository.GetTenderScores  - r.db.Builder.ToSql: %v", err)
+	}
+
+	return r.queryScores(ctx, "EvaluationRepository.GetTenderScores", query, args)
+}
+
+func (r *EvaluationRepository) queryScores(ctx context.Context, op string, query string, args []any) ([]entity.BidScore, error) {
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s - r.db.Conn().Query: %v", op, err)
+	}
+	defer rows.Close()
+
+	var scores []entity.BidScore
+	for rows.Next() {
+		var score entity.BidScore
+		err := rows.Scan(
+			&score.Id,
+			&score.BidId,
+			&score.CriterionId,
+			&score.UserId,
+			&score.Score,
+			&score.Comment,
+			&score.BidVersion,
+			&score.Version,
+			&score.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s - rows.Scan: %v", op, err)
+		}
+		scores = append(scores, score)
+	}
+
+	return scores, nil
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"tender-service/internal/entity"
+)
+
+const eventsChannel = "domain_events"
+
+type EventRepository struct {
+	db *Postgres
+}
+
+func NewEventRepository(db *Postgres) *EventRepository {
+	return &EventRepository{db: db}
+}
+
+func (r *EventRepository) NotifyEvent(ctx context.Context, event entity.Event) error {
+	ctx, span := tracer.Start(ctx, "EventRepository.NotifyEvent")
+	defer span.End()
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("EventRepository.NotifyEvent - json.Marshal: %v", err)
+	}
+
+	_, err = r.db.Conn().Exec(ctx, "SELECT pg_notify($1, $2)", eventsChannel, string(payload))
+	if err != nil {
+		return fmt.Errorf("EventRepository.NotifyEvent - r.db.Conn().Exec: %v", err)
+	}
+
+	return nil
+}
+
+func (r *EventRepository) ListenEvents(ctx context.Context, handle func(event entity.Event)) error {
+	poolConn, err := r.db.Pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("EventRepository.ListenEvents - r.db.Pool.Acquire: %v", err)
+	}
+	conn := poolConn.Hijack()
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	_, err = conn.Exec(ctx, "LISTEN "+eventsChannel)
+	if err != nil {
+		return fmt.Errorf("EventRepository.ListenEvents - conn.Exec: %v", err)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("EventRepository.ListenEvents - conn.WaitForNotification: %v", err)
+		}
+
+		var event entity.Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			continue
+		}
+		handle(event)
+	}
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"tender-service/internal/repository/repoerrs"
+)
+
+const SchemaVersion = 17
+
+type HealthRepository struct {
+	db *Postgres
+}
+
+func NewHealthRepository(db *Postgres) *HealthRepository {
+	return &HealthRepository{db: db}
+}
+
+func (r *HealthRepository) Ping(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "HealthRepository.Ping")
+	defer span.End()
+
+	if err := r.db.Pool.Ping(ctx); err != nil {
+		return fmt.Errorf("HealthRepository.Ping - r.db.Pool.Ping: %v", err)
+	}
+	return nil
+}
+
+func (r *HealthRepository) GetMigrationVersion(ctx context.Context) (int, bool, error) {
+	ctx, span := tracer.Start(ctx, "HealthRepository.GetMigrationVersion")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("version", "dirty").
+		From("schema_migrations").
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return 0, false, fmt.Errorf("HealthRepository.GetMigrationVersion - r.db.Builder.ToSql: %v", err)
+	}
+
+	var version int
+	var dirty bool
+	err = r.db.Pool.QueryRow(ctx, query, args...).Scan(&version, &dirty)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, repoerrs.ErrNotFound
+		}
+		return 0, false, fmt.Errorf("HealthRepository.GetMigrationVersion - r.db.Pool.QueryRow: %v", err)
+	}
+
+	return version, dirty, nil
+}
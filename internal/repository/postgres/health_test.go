@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSchemaVersionMatchesMigrations(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "..", "migrations", "*.up.sql"))
+	if err != nil {
+		t.Fatalf("filepath.Glob: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no migrations found")
+	}
+
+	latest := 0
+	for _, file := range files {
+		prefix, _, ok := strings.Cut(filepath.Base(file), "_")
+		if !ok {
+			t.Fatalf("migration %s has no version prefix", file)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			t.Fatalf("migration %s has invalid version: %v", file, err)
+		}
+		if _, err := os.Stat(strings.TrimSuffix(file, ".up.sql") + ".down.sql"); err != nil {
+			t.Errorf("migration %s has no down migration: %v", file, err)
+		}
+		latest = max(latest, version)
+	}
+
+	if SchemaVersion != latest {
+		t.Errorf("SchemaVersion = %d, latest migration = %d", SchemaVersion, latest)
+	}
+}
@@ -0,0 +1,356 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"tender-service/internal/entity"
+	"tender-service/internal/repository/repoerrs"
+)
+
+type LotRepository struct {
+	db *Postgres
+}
+
+func NewLotRepository(db *Postgres) *LotRepository {
+	return &LotRepository{db: db}
+}
+
+func (r *LotRepository) CreateLots(ctx context.Context, tenderId string, lots []entity.Lot) ([]entity.Lot, error) {
+	ctx, span := tracer.Start(ctx, "LotRepository.CreateLots")
+	defer span.End()
+
+	for i, lot := range lots {
+		query, args, err := r.db.Builder.
+			Insert("tender_lots").
+			Columns("tender_id", "number", "name", "description", "service_type", "budget").
+			Values(
+				tenderId,
+				lot.Number,
+				lot.Name,
+				lot.Description,
+				lot.ServiceType,
+				lot.Budget,
+			).
+			Suffix("RETURNING id, status, created_at").
+			ToSql()
+
+		if err != nil {
+			return nil, fmt.Errorf("LotRepository.CreateLots  - r.db.Builder.ToSql: %v", err)
+		}
+
+		err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+			&lots[i].Id,
+			&lots[i].Status,
+			&lots[i].CreatedAt,
+		)
+		if err != nil {
+			if isPgError(err, uniqueViolationCode) {
+				return nil, repoerrs.ErrAlreadyExists
+			}
+			return nil, fmt.Errorf("LotRepository.CreateLots - r.db.Conn().QueryRow: %v", err)
+		}
+		lots[i].TenderId = tenderId
+	}
+
+	return lots, nil
+}
+
+func (r *LotRepository) GetLots(ctx context.Context, tenderId string) ([]entity.Lot, error) {
+	ctx, span := tracer.Start(ctx, "LotRepository.GetLots")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id", "tender_id", "number", "name", "description", "service_type", "budget", "status", "awarded_bid_id", "created_at").
+		From("tender_lots").
+		Where("tender_id = ?", tenderId).
+		OrderBy("number ASC").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("LotRepository.GetLots  - r.db.Builder.ToSql: %v", err)
+	}
+
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("LotRepository.GetLots - r.db.Conn().Query: %v", err)
+	}
+	defer rows.Close()
+
+	var lots []entity.Lot
+	for rows.Next() {
+		var lot entity.Lot
+		err := rows.Scan(
+			&lot.Id,
+			&lot.TenderId,
+			&lot.Number,
+			&lot.Name,
+			&lot.Description,
+			&lot.ServiceType,
+			&lot.Budget,
+			&lot.Status,
+			&lot.AwardedBidId,
+			&lot.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("LotRepository.GetLots - rows.Scan: %v", err)
+		}
+		lots = append(lots, lot)
+	}
+
+	return lots, nil
+}
+
+func (r *LotRepository) GetLotById(ctx context.Context, lotId string) (entity.Lot, error) {
+	ctx, span := tracer.Start(ctx, "LotRepository.GetLotById")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id", "tender_id", "number", "name", "description", "service_type", "budget", "status", "awarded_bid_id", "created_at").
+		From("tender_lots").
+		Where("id = ?", lotId).
+		ToSql()
+
+	if err != nil {
+		return entity.Lot{}, fmt.Errorf("LotRepository.GetLotById  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var lot entity.Lot
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&lot.Id,
+		&lot.TenderId,
+		&lot.Number,
+		&lot.Name,
+		&lot.Description,
+		&lot.ServiceType,
+		&lot.Budget,
+		&lot.Status,
+		&lot.AwardedBidId,
+		&lot.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Lot{}, repoerrs.ErrNotFound
+		}
+		return entity.Lot{}, fmt.Errorf("LotRepository.GetLotById - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return lot, nil
+}
+
+func (r *LotRepository) CloseLot(ctx context.Context, lotId string, status string, awardedBidId *string) (entity.Lot, error) {
+	ctx, span := tracer.Start(ctx, "LotRepository.CloseLot")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Update("tender_lots").
+		Set("status", status).
+		Set("awarded_bid_id", awardedBidId).
+		Set("updated_at", currentTimestamp).
+		Where("id = ?", lotId).
+		Where("status = ?", "Open").
+		Suffix("RETURNING id, tender_id, number, name, description, service_type, budget, status, awarded_bid_id, created_at").
+		ToSql()
+
+	if err != nil {
+		return entity.Lot{}, fmt.Errorf("LotRepository.CloseLot  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var lot entity.Lot
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&lot.Id,
+		&lot.TenderId,
+		&lot.Number,
+		&lot.Name,
+		&lot.Description,
+		&lot.ServiceType,
+		&lot.Budget,
+		&lot.Status,
+		&lot.AwardedBidId,
+		&lot.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Lot{}, repoerrs.ErrNotFound
+		}
+		return entity.Lot{}, fmt.Errorf("LotRepository.CloseLot - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return lot, nil
+}
+
+func (r *LotRepository) CountOpenLots(ctx context.Context, tenderId string) (int, error) {
+	ctx, span := tracer.Start(ctx, "LotRepository.CountOpenLots")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("COUNT(*)").
+		From("tender_lots").
+		Where("tender_id = ?", tenderId).
+		Where("status = ?", "Open").
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("LotRepository.CountOpenLots  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var count int
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("LotRepository.CountOpenLots - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return count, nil
+}
+
+func (r *LotRepository) CancelOpenLots(ctx context.Context, tenderId string) (int, error) {
+	ctx, span := tracer.Start(ctx, "LotRepository.CancelOpenLots")
+	defer span.End()
+
+	query, args, err := cancelOpenLotsQuery(r.db, tenderId)
+	if err != nil {
+		return 0, fmt.Errorf("LotRepository.CancelOpenLots  - r.db.Builder.ToSql: %v", err)
+	}
+
+	tag, err := r.db.Conn().Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("LotRepository.CancelOpenLots - r.db.Conn().Exec: %v", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+func (r *LotRepository) CreateBidLots(ctx context.Context, bidLots []entity.BidLot) error {
+	ctx, span := tracer.Start(ctx, "LotRepository.CreateBidLots")
+	defer span.End()
+
+	if len(bidLots) == 0 {
+		return nil
+	}
+
+	insert := r.db.Builder.
+		Insert("bid_lots").
+		Columns("bid_id", "lot_id", "price")
+	for _, bidLot := range bidLots {
+		insert = insert.Values(bidLot.BidId, bidLot.LotId, bidLot.Price)
+	}
+
+	query, args, err := insert.ToSql()
+	if err != nil {
+		return fmt.Errorf("LotRepository.CreateBidLots  - r.db.Builder.ToSql: %v", err)
+	}
+
+	_, err = r.db.Conn().Exec(ctx, query, args...)
+	if err != nil {
+		if isPgError(err, uniqueViolationCode) {
+			return repoerrs.ErrAlreadyExists
+		}
+		return fmt.Errorf("LotRepository.CreateBidLots - r.db.Conn().Exec: %v", err)
+	}
+
+	return nil
+}
+
+func (r *LotRepository) GetBidLots(ctx context.Context, bidId string) ([]entity.BidLot, error) {
+	ctx, span := tracer.Start(ctx, "LotRepository.GetBidLots")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("bl.bid_id", "bl.lot_id", "bl.price", "bl.decision::text").
+		From("bid_lots bl").
+		Join("tender_lots l ON l.id = bl.lot_id").
+		Where("bl.bid_id = ?", bidId).
+		OrderBy("l.number ASC").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("LotRepository.GetBidLots  - r.db.Builder.ToSql: %v", err)
+	}
+
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("LotRepository.GetBidLots - r.db.Conn().Query: %v", err)
+	}
+	defer rows.Close()
+
+	var bidLots []entity.BidLot
+	for rows.Next() {
+		var bidLot entity.BidLot
+		err := rows.Scan(
+			&bidLot.BidId,
+			&bidLot.LotId,
+			&bidLot.Price,
+			&bidLot.Decision,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("LotRepository.GetBidLots - rows.Scan: %v", err)
+		}
+		bidLots = append(bidLots, bidLot)
+	}
+
+	return bidLots, nil
+}
+
+func (r *LotRepository) UpdateBidLotPrice(ctx context.Context, bidLot entity.BidLot) error {
+	ctx, span := tracer.Start(ctx, "LotRepository.UpdateBidLotPrice")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Update("bid_lots").
+		Set("price", bidLot.Price).
+		Where("bid_id = ?", bidLot.BidId).
+		Where("lot_id = ?", bidLot.LotId).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("LotRepository.UpdateBidLotPrice  - r.db.Builder.ToSql: %v", err)
+	}
+
+	tag, err := r.db.Conn().Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("LotRepository.UpdateBidLotPrice - r.db.Conn().Exec: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *LotRepository) SubmitBidLotDecision(ctx context.Context, bidId string, lotId string, decision string) error {
+	ctx, span := tracer.Start(ctx, "LotRepository.SubmitBidLotDecision")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Update("bid_lots").
+		Set("decision", decision).
+		Where("bid_id = ?", bidId).
+		Where("lot_id = ?", lotId).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("LotRepository.SubmitBidLotDecision  - r.db.Builder.ToSql: %v", err)
+	}
+
+	tag, err := r.db.Conn().Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("LotRepository.SubmitBidLotDecision - r.db.Conn().Exec: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+func cancelOpenLotsQuery(db *Postgres, tenderIds ...string) (string, []any, error) {
+	return db.Builder.
+		Update("tender_lots").
+		Set("status", "Cancelled").
+		Set("updated_at", currentTimestamp).
+		Where(squirrel.Eq{"tender_id": tenderIds}).
+		Where("status = ?", "Open").
+		ToSql()
+}
@@ -0,0 +1,283 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"tender-service/internal/entity"
+	"tender-service/internal/repository/repoerrs"
+)
+
+func (r *AuthRepository) CreateOrganization(ctx context.Context, organization entity.Organization, ownerId string) (entity.Organization, error) {
+	ctx, span := tracer.Start(ctx, "AuthRepository.CreateOrganization")
+	defer span.End()
+
+	tx, err := r.db.Conn().Begin(ctx)
+	if err != nil {
+		return entity.Organization{}, fmt.Errorf("AuthRepository.CreateOrganization - r.Pool.Begin: %v", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	createOrganization, args, err := r.db.Builder.
+		Insert("organization").
+		Columns("name", "description", "type").
+		Values(
+			organization.Name,
+			organization.Description,
+			organization.Type,
+		).
+		Suffix("RETURNING id, created_at, updated_at").
+		ToSql()
+
+	if err != nil {
+		return entity.Organization{}, fmt.Errorf("AuthRepository.CreateOrganization  - r.db.Builder.ToSql: %v", err)
+	}
+
+	err = tx.QueryRow(ctx, createOrganization, args...).Scan(
+		&organization.Id,
+		&organization.CreatedAt,
+		&organization.UpdatedAt,
+	)
+	if err != nil {
+		return entity.Organization{}, fmt.Errorf("AuthRepository.CreateOrganization  - tx.QueryRow: %v", err)
+	}
+
+	createOwner, args, err := r.db.Builder.
+		Insert("organization_responsible").
+		Columns("organization_id", "user_id", "role").
+		Values(organization.Id, ownerId, entity.RoleOwner).
+		ToSql()
+
+	if err != nil {
+		return entity.Organization{}, fmt.Errorf("AuthRepository.CreateOrganization  - r.db.Builder.ToSql: %v", err)
+	}
+
+	_, err = tx.Exec(ctx, createOwner, args...)
+	if err != nil {
+		return entity.Organization{}, fmt.Errorf("AuthRepository.CreateOrganization - tx.Exec: %v", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Organization{}, fmt.Errorf("AuthRepository.CreateOrganization - tx.Commit: %v", err)
+	}
+
+	return organization, nil
+}
+
+func (r *AuthRepository) GetOrganizations(ctx context.Context, limit int, offset int) ([]entity.Organization, error) {
+	ctx, span := tracer.Start(ctx, "AuthRepository.GetOrganizations")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id", "name", "COALESCE(description, '')", "COALESCE(type::text, '')", "created_at", "updated_at").
+		From("organization").
+		OrderBy("name ASC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("AuthRepository.GetOrganizations  - r.db.Builder.ToSql: %v", err)
+	}
+
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("AuthRepository.GetOrganizations - r.db.Conn().Query: %v", err)
+	}
+	defer rows.Close()
+
+	var organizations []entity.Organization
+	for rows.Next() {
+		var organization entity.Organization
+		err := rows.Scan(
+			&organization.Id,
+			&organization.Name,
+			&organization.Description,
+			&organization.Type,
+			&organization.CreatedAt,
+			&organization.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("AuthRepository.GetOrganizations - rows.Scan: %v", err)
+		}
+		organizations = append(organizations, organization)
+	}
+
+	return organizations, nil
+}
+
+func (r *AuthRepository) GetOrganizationById(ctx context.Context, id string) (entity.Organization, error) {
+	ctx, span := tracer.Start(ctx, "AuthRepository.GetOrganizationById")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id", "name", "COALESCE(description, '')", "COALESCE(type::text, '')", "created_at", "updated_at").
+		From("organization").
+		Where("id = ?", id).
+		ToSql()
+
+	if err != nil {
+		return entity.Organization{}, fmt.Errorf("AuthRepository.GetOrganizationById  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var organization entity.Organization
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&organization.Id,
+		&organization.Name,
+		&organization.Description,
+		&organization.Type,
+		&organization.CreatedAt,
+		&organization.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Organization{}, repoerrs.ErrNotFound
+		}
+		return entity.Organization{}, fmt.Errorf("AuthRepository.GetOrganizationById  - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return organization, nil
+}
+
+func (r *AuthRepository) UpdateOrganization(ctx context.Context, id string, input entity.EditOrganizationInput) (entity.Organization, error) {
+	ctx, span := tracer.Start(ctx, "AuthRepository.UpdateOrganization")
+	defer span.End()
+
+	updateOrganizationQuery := r.db.Builder.
+		Update("organization").
+		Set("updated_at", currentTimestamp).
+		Where("id = ?", id)
+	if input.Name != nil {
+		updateOrganizationQuery = updateOrganizationQuery.Set("name", *input.Name)
+	}
+	if input.Description != nil {
+		updateOrganizationQuery = updateOrganizationQuery.Set("description", *input.Description)
+	}
+	if input.Type != nil {
+		updateOrganizationQuery = updateOrganizationQuery.Set("type", *input.Type)
+	}
+
+	query, args, err := updateOrganizationQuery.
+		Suffix("RETURNING id, name, COALESCE(description, ''), COALESCE(type::text, ''), created_at, updated_at").
+		ToSql()
+
+	if err != nil {
+		return entity.Organization{}, fmt.Errorf("AuthRepository.UpdateOrganization  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var organization entity.Organization
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&organization.Id,
+		&organization.Name,
+		&organization.Description,
+		&organization.Type,
+		&organization.CreatedAt,
+		&organization.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Organization{}, repoerrs.ErrNotFound
+		}
+		return entity.Organization{}, fmt.Errorf("AuthRepository.UpdateOrganization  - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return organization, nil
+}
+
+func (r *AuthRepository) DeleteOrganization(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "AuthRepository.DeleteOrganization")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Delete("organization").
+		Where("id = ?", id).
+		Where("NOT EXISTS (SELECT 1 FROM tenders WHERE organization_id = ?)", id).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("AuthRepository.DeleteOrganization  - r.db.Builder.ToSql: %v", err)
+	}
+
+	tag, err := r.db.Conn().Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("AuthRepository.DeleteOrganization - r.db.Conn().Exec: %v", err)
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
+	query, args, err = r.db.Builder.
+		Select().
+		Column("EXISTS (SELECT 1 FROM tenders WHERE organization_id = ?)", id).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("AuthRepository.DeleteOrganization  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var hasTenders bool
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(&hasTenders)
+	if err != nil {
+		return fmt.Errorf("AuthRepository.DeleteOrganization  - r.db.Conn().QueryRow: %v", err)
+	}
+	if hasTenders {
+		return repoerrs.ErrHasDependents
+	}
+
+	return repoerrs.ErrNotFound
+}
+
+func (r *AuthRepository) AddResponsible(ctx context.Context, organizationId string, userId string, role string) error {
+	ctx, span := tracer.Start(ctx, "AuthRepository.AddResponsible")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Insert("organization_responsible").
+		Columns("organization_id", "user_id", "role").
+		Values(organizationId, userId, role).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("AuthRepository.AddResponsible  - r.db.Builder.ToSql: %v", err)
+	}
+
+	_, err = r.db.Conn().Exec(ctx, query, args...)
+	if err != nil {
+		if isPgError(err, uniqueViolationCode) {
+			return repoerrs.ErrAlreadyExists
+		}
+		if isPgError(err, foreignKeyViolationCode) {
+			return repoerrs.ErrNotFound
+		}
+		return fmt.Errorf("AuthRepository.AddResponsible - r.db.Conn().Exec: %v", err)
+	}
+
+	return nil
+}
+
+func (r *AuthRepository) RemoveResponsible(ctx context.Context, organizationId string, userId string) error {
+	ctx, span := tracer.Start(ctx, "AuthRepository.RemoveResponsible")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Delete("organization_responsible").
+		Where("organization_id = ?", organizationId).
+		Where("user_id = ?", userId).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("AuthRepository.RemoveResponsible  - r.db.Builder.ToSql: %v", err)
+	}
+
+	tag, err := r.db.Conn().Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("AuthRepository.RemoveResponsible - r.db.Conn().Exec: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
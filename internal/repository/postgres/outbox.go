@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"tender-service/internal/entity"
+)
+
+const outboxRelayLock = 7281934501
+
+var tenderStatusEvents = map[string]string{
+	"Published": entity.EventTenderPublished,
+	"Closed":    entity.EventTenderClosed,
+}
+
+type OutboxRepository struct {
+	db *Postgres
+}
+
+func NewOutboxRepository(db *Postgres) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+func (r *OutboxRepository) TryLockRelay(ctx context.Context) (bool, error) {
+	ctx, span := tracer.Start(ctx, "OutboxRepository.TryLockRelay")
+	defer span.End()
+
+	var locked bool
+	err := r.db.Conn().QueryRow(ctx, "SELECT pg_try_advisory_xact_lock($1)", outboxRelayLock).Scan(&locked)
+	if err != nil {
+		return false, fmt.Errorf("OutboxRepository.TryLockRelay - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return locked, nil
+}
+
+// GetUnpublishedEvents returns events in transaction id order, and in insertion order within one transaction. Only
+// events written by transactions older than every transaction still in progress are returned: a concurrent
+// transaction with a smaller id could otherwise commit later and add events that belong before the ones already
+// relayed.
+func (r *OutboxRepository) GetUnpublishedEvents(ctx context.Context, limit int) ([]entity.Event, error) {
+	ctx, span := tracer.Start(ctx, "OutboxRepository.GetUnpublishedEvents")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id", "event_id", "event_type", "payload", "created_at").
+		From("outbox").
+		Where("published_at IS NULL").
+		Where("txid < pg_snapshot_xmin(pg_current_snapshot())").
+		OrderBy("txid ASC", "id ASC").
+		Limit(uint64(limit)).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("OutboxRepository.GetUnpublishedEvents  - r.db.Builder.ToSql: %v", err)
+	}
+
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("OutboxRepository.GetUnpublishedEvents - r.db.Conn().Query: %v", err)
+	}
+	defer rows.Close()
+
+	var events []entity.Event
+	for rows.Next() {
+		var event entity.Event
+		err := rows.Scan(
+			&event.Sequence,
+			&event.Id,
+			&event.Type,
+			&event.Data,
+			&event.OccurredAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("OutboxRepository.GetUnpublishedEvents - rows.Scan: %v", err)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, sequences []int64) error {
+	ctx, span := tracer.Start(ctx, "OutboxRepository.MarkPublished")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Update("outbox").
+		Set("published_at", currentTimestamp).
+		Where("id = ANY(?)", sequences).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("OutboxRepository.MarkPublished  - r.db.Builder.ToSql: %v", err)
+	}
+
+	_, err = r.db.Conn().Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("OutboxRepository.MarkPublished - r.db.Conn().Exec: %v", err)
+	}
+
+	return nil
+}
+
+func insertEvents(ctx context.Context, db *Postgres, conn Querier, events ...entity.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	insert := db.Builder.
+		Insert("outbox").
+		Columns("event_type", "payload")
+	for _, event := range events {
+		payload, err := json.Marshal(event.Data)
+		if err != nil {
+			return fmt.Errorf("insertEvents - json.Marshal: %v", err)
+		}
+		insert = insert.Values(event.Type, string(payload))
+	}
+
+	query, args, err := insert.ToSql()
+	if err != nil {
+		return fmt.Errorf("insertEvents - db.Builder.ToSql: %v", err)
+	}
+
+	_, err = conn.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("insertEvents - conn.Exec: %v", err)
+	}
+
+	return nil
+}
+
+func tenderEvent(eventType string, tender entity.Tender) entity.Event {
+	return entity.Event{
+		Type: eventType,
+		Data: entity.EventData{
+			TenderId: tender.Id,
+			Status:   tender.Status,
+			Version:  tender.Version,
+		},
+	}
+}
+
+func bidEvent(eventType string, bid entity.Bid, decision string) entity.Event {
+	return entity.Event{
+		Type: eventType,
+		Data: entity.EventData{
+			TenderId: bid.TenderId,
+			BidId:    bid.Id,
+			Status:   bid.Status,
+			Version:  bid.Version,
+			Decision: decision,
+		},
+	}
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"tender-service/internal/entity"
+	"tender-service/internal/repository/repoerrs"
+)
+
+type keyset struct {
+	column string
+	cast   string
+	desc   bool
+	sort   string
+}
+
+type cursorToken struct {
+	Sort string `json:"s"`
+	Key  string `json:"k"`
+	Id   string `json:"i"`
+}
+
+func (k keyset) paginate(builder squirrel.SelectBuilder, page entity.PageRequest) (squirrel.SelectBuilder, error) {
+	order := "ASC"
+	if k.desc {
+		order = "DESC"
+	}
+	builder = builder.
+		Column(k.column+"::text").
+		OrderBy(k.column+" "+order, "id "+order).
+		Limit(uint64(page.Limit) + 1)
+
+	if page.Cursor == nil {
+		return builder.Offset(uint64(page.Offset)), nil
+	}
+	if *page.Cursor == "" {
+		return builder, nil
+	}
+
+	token, err := decodeCursor(*page.Cursor)
+	if err != nil || token.Sort != k.sort {
+		return builder, repoerrs.ErrInvalidCursor
+	}
+
+	op := ">"
+	if k.desc {
+		op = "<"
+	}
+	return builder.Where(
+		fmt.Sprintf("(%s, id) %s (CAST(? AS TEXT)::%s, CAST(? AS TEXT)::uuid)", k.column, op, k.cast),
+		token.Key, token.Id,
+	), nil
+}
+
+func pageOf[T any](k keyset, items []T, keys []string, id func(T) string, limit int) entity.Page[T] {
+	page := entity.Page[T]{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		if limit > 0 {
+			page.NextCursor = encodeCursor(cursorToken{Sort: k.sort, Key: keys[limit-1], Id: id(items[limit-1])})
+		}
+	}
+	return page
+}
+
+func encodeCursor(token cursorToken) string {
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(cursor string) (cursorToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return cursorToken{}, err
+	}
+
+	var token cursorToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return cursorToken{}, err
+	}
+	if token.Id == "" {
+		return cursorToken{}, fmt.Errorf("cursor has no id")
+	}
+
+	return token, nil
+}
@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"errors"
+	"github.com/Masterminds/squirrel"
+	"strings"
+	"tender-service/internal/entity"
+	"tender-service/internal/repository/repoerrs"
+	"testing"
+)
+
+var testKeyset = keyset{column: "created_at", cast: "timestamp", desc: true, sort: "tenders:created_at:desc"}
+
+func TestCursorRoundTrip(t *testing.T) {
+	tokens := []cursorToken{
+		{Sort: "name:asc", Key: "Tender 1", Id: "550e8400-e29b-41d4-a716-446655440001"},
+		{Sort: "created_at:desc", Key: "2024-09-14 14:06:05.045554", Id: "550e8400-e29b-41d4-a716-446655440002"},
+		{Sort: "price:asc", Key: "", Id: "550e8400-e29b-41d4-a716-446655440003"},
+		{Sort: "name:asc", Key: "строка с \"кавычками\" и /?&=", Id: "550e8400-e29b-41d4-a716-446655440004"},
+	}
+
+	for _, token := range tokens {
+		cursor := encodeCursor(token)
+		if strings.ContainsAny(cursor, "+/=") {
+			t.Errorf("encodeCursor(%+v) = %q, want URL-safe cursor", token, cursor)
+		}
+
+		got, err := decodeCursor(cursor)
+		if err != nil {
+			t.Fatalf("decodeCursor(%q) error = %v", cursor, err)
+		}
+		if got != token {
+			t.Errorf("decodeCursor(encodeCursor(%+v)) = %+v", token, got)
+		}
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "not a cursor!"},
+		{name: "not json", cursor: "bm90IGpzb24"},
+		{name: "no id", cursor: encodeCursor(cursorToken{Sort: "name:asc", Key: "a"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.cursor); err == nil {
+				t.Errorf("decodeCursor(%q) error = nil, want error", tt.cursor)
+			}
+		})
+	}
+}
+
+func TestKeysetPaginate(t *testing.T) {
+	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select("id").From("tenders")
+	cursor := func(value string) *string { return &value }
+
+	tests := []struct {
+		name     string
+		page     entity.PageRequest
+		wantErr  error
+		wantSql  string
+		wantArgs int
+	}{
+		{
+			name:    "offset paging",
+			page:    entity.PageRequest{Limit: 5, Offset: 10},
+			wantSql: "SELECT id, created_at::text FROM tenders ORDER BY created_at DESC, id DESC LIMIT 6 OFFSET 10",
+		},
+		{
+			name:    "first cursor page",
+			page:    entity.PageRequest{Limit: 5, Cursor: cursor("")},
+			wantSql: "SELECT id, created_at::text FROM tenders ORDER BY created_at DESC, id DESC LIMIT 6",
+		},
+		{
+			name: "next cursor page",
+			page: entity.PageRequest{Limit: 5, Cursor: cursor(encodeCursor(cursorToken{
+				Sort: "tenders:created_at:desc", Key: "2024-09-14 14:06:05", Id: "550e8400-e29b-41d4-a716-446655440001",
+			}))},
+			wantSql: "SELECT id, created_at::text FROM tenders " +
+				"WHERE (created_at, id) < (CAST($1 AS TEXT)::timestamp, CAST($2 AS TEXT)::uuid) " +
+				"ORDER BY created_at DESC, id DESC LIMIT 6",
+			wantArgs: 2,
+		},
+		{
+			name: "cursor for another sort",
+			page: entity.PageRequest{Limit: 5, Cursor: cursor(encodeCursor(cursorToken{
+				Sort: "tenders:name:asc", Key: "Tender", Id: "550e8400-e29b-41d4-a716-446655440001",
+			}))},
+			wantErr: repoerrs.ErrInvalidCursor,
+		},
+		{
+			name:    "malformed cursor",
+			page:    entity.PageRequest{Limit: 5, Cursor: cursor("garbage")},
+			wantErr: repoerrs.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testKeyset.paginate(builder, tt.page)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("paginate() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("paginate() error = %v", err)
+			}
+
+			sql, args, err := got.ToSql()
+			if err != nil {
+				t.Fatalf("ToSql() error = %v", err)
+			}
+			if sql != tt.wantSql {
+				t.Errorf("paginate() sql = %q, want %q", sql, tt.wantSql)
+			}
+			if len(args) != tt.wantArgs {
+				t.Errorf("paginate() args = %v, want %d args", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestPageOf(t *testing.T) {
+	id := func(item string) string { return item }
+
+	tests := []struct {
+		name      string
+		items     []string
+		limit     int
+		wantItems []string
+		wantKey   string
+	}{
+		{name: "empty", items: nil, limit: 2, wantItems: nil},
+		{name: "fewer than limit", items: []string{"a"}, limit: 2, wantItems: []string{"a"}},
+		{name: "exactly limit", items: []string{"a", "b"}, limit: 2, wantItems: []string{"a", "b"}},
+		{name: "limit plus one", items: []string{"a", "b", "c"}, limit: 2, wantItems: []string{"a", "b"}, wantKey: "b"},
+		{name: "zero limit", items: []string{"a"}, limit: 0, wantItems: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := make([]string, len(tt.items))
+			for i, item := range tt.items {
+				keys[i] = "key-" + item
+			}
+
+			page := pageOf(testKeyset, tt.items, keys, id, tt.limit)
+			if strings.Join(page.Items, ",") != strings.Join(tt.wantItems, ",") || len(page.Items) != len(tt.wantItems) {
+				t.Errorf("pageOf() items = %v, want %v", page.Items, tt.wantItems)
+			}
+
+			if tt.wantKey == "" {
+				if page.NextCursor != "" {
+					t.Errorf("pageOf() next cursor = %q, want none", page.NextCursor)
+				}
+				return
+			}
+			token, err := decodeCursor(page.NextCursor)
+			if err != nil {
+				t.Fatalf("decodeCursor(next cursor) error = %v", err)
+			}
+			want := cursorToken{Sort: testKeyset.sort, Key: "key-" + tt.wantKey, Id: tt.wantKey}
+			if token != want {
+				t.Errorf("pageOf() next cursor = %+v, want %+v", token, want)
+			}
+		})
+	}
+}
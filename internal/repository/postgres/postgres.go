@@ -11,6 +11,8 @@ import (
 	"time"
 )
 
+var currentTimestamp = squirrel.Expr("CURRENT_TIMESTAMP")
+
 const (
 	defaultMaxPoolSize  = 1
 	defaultConnAttempts = 10
@@ -28,6 +30,14 @@ type PgxPool interface {
 	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
 	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
 	Ping(ctx context.Context) error
+	Stat() *pgxpool.Stat
+}
+
+type Querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
 type Postgres struct {
@@ -37,6 +47,8 @@ type Postgres struct {
 
 	Builder squirrel.StatementBuilderType
 	Pool    PgxPool
+
+	tx pgx.Tx
 }
 
 func NewPostgresDB(url string, opts ...Option) (*Postgres, error) {
@@ -58,6 +70,7 @@ func NewPostgresDB(url string, opts ...Option) (*Postgres, error) {
 	}
 
 	poolConfig.MaxConns = int32(pg.maxPoolSize)
+	poolConfig.ConnConfig.Tracer = queryTracer{}
 
 	for pg.connAttempts > 0 {
 		pg.Pool, err = pgxpool.NewWithConfig(context.Background(), poolConfig)
@@ -77,6 +90,19 @@ func NewPostgresDB(url string, opts ...Option) (*Postgres, error) {
 	return pg, nil
 }
 
+func (p *Postgres) WithTx(tx pgx.Tx) *Postgres {
+	txPostgres := *p
+	txPostgres.tx = tx
+	return &txPostgres
+}
+
+func (p *Postgres) Conn() Querier {
+	if p.tx != nil {
+		return p.tx
+	}
+	return p.Pool
+}
+
 func (p *Postgres) Close() {
 	if p.Pool != nil {
 		p.Pool.Close()
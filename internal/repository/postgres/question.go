@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"tender-service/internal/entity"
+	"tender-service/internal/repository/repoerrs"
+)
+
+type QuestionRepository struct {
+	db *Postgres
+}
+
+func NewQuestionRepository(db *Postgres) *QuestionRepository {
+	return &QuestionRepository{db: db}
+}
+
+func (r *QuestionRepository) CreateQuestion(ctx context.Context, question entity.Question) (entity.Question, error) {
+	ctx, span := tracer.Start(ctx, "QuestionRepository.CreateQuestion")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Insert("tender_questions").
+		Columns("tender_id", "author_id", "text").
+		Values(
+			question.TenderId,
+			question.AuthorId,
+			question.Text,
+		).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	if err != nil {
+		return entity.Question{}, fmt.Errorf("QuestionRepository.CreateQuestion  - r.db.Builder.ToSql: %v", err)
+	}
+
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&question.Id,
+		&question.CreatedAt,
+	)
+	if err != nil {
+		return entity.Question{}, fmt.Errorf("QuestionRepository.CreateQuestion - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return question, nil
+}
+
+func (r *QuestionRepository) GetQuestions(ctx context.Context, tenderId string) ([]entity.Question, error) {
+	ctx, span := tracer.Start(ctx, "QuestionRepository.GetQuestions")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id", "tender_id", "author_id", "text", "answer", "visibility::text", "answered_by", "answered_at", "created_at").
+		From("tender_questions").
+		Where("tender_id = ?", tenderId).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("QuestionRepository.GetQuestions  - r.db.Builder.ToSql: %v", err)
+	}
+
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("QuestionRepository.GetQuestions - r.db.Conn().Query: %v", err)
+	}
+	defer rows.Close()
+
+	var questions []entity.Question
+	for rows.Next() {
+		var question entity.Question
+		err := rows.Scan(
+			&question.Id,
+			&question.TenderId,
+			&question.AuthorId,
+			&question.Text,
+			&question.Answer,
+			&question.Visibility,
+			&question.AnsweredBy,
+			&question.AnsweredAt,
+			&question.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("QuestionRepository.GetQuestions - rows.Scan: %v", err)
+		}
+		questions = append(questions, question)
+	}
+
+	return questions, nil
+}
+
+func (r *QuestionRepository) GetQuestionById(ctx context.Context, questionId string) (entity.Question, error) {
+	ctx, span := tracer.Start(ctx, "QuestionRepository.GetQuestionById")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id", "tender_id", "author_id", "text", "answer", "visibility::text", "answered_by", "answered_at", "created_at").
+		From("tender_questions").
+		Where("id = ?", questionId).
+		ToSql()
+
+	if err != nil {
+		return entity.Question{}, fmt.Errorf("QuestionRepository.GetQuestionById  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var question entity.Question
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&question.Id,
+		&question.TenderId,
+		&question.AuthorId,
+		&question.Text,
+		&question.Answer,
+		&question.Visibility,
+		&question.AnsweredBy,
+		&question.AnsweredAt,
+		&question.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Question{}, repoerrs.ErrNotFound
+		}
+		return entity.Question{}, fmt.Errorf("QuestionRepository.GetQuestionById - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return question, nil
+}
+
+func (r *QuestionRepository) AnswerQuestion(ctx context.Context, questionId string, answer string, visibility string, userId string) (entity.Question, error) {
+	ctx, span := tracer.Start(ctx, "QuestionRepository.AnswerQuestion")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Update("tender_questions").
+		Set("answer", answer).
+		Set("visibility", visibility).
+		Set("answered_by", userId).
+		Set("answered_at", currentTimestamp).
+		Where("id = ?", questionId).
+		Suffix("RETURNING id, tender_id, author_id, text, answer, visibility::text, answered_by, answered_at, created_at").
+		ToSql()
+
+	if err != nil {
+		return entity.Question{}, fmt.Errorf("QuestionRepository.AnswerQuestion  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var question entity.Question
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&question.Id,
+		&question.TenderId,
+		&question.AuthorId,
+		&question.Text,
+		&question.Answer,
+		&question.Visibility,
+		&question.AnsweredBy,
+		&question.AnsweredAt,
+		&question.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Question{}, repoerrs.ErrNotFound
+		}
+		return entity.Question{}, fmt.Errorf("QuestionRepository.AnswerQuestion - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return question, nil
+}
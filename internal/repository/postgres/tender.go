@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
 	"tender-service/internal/entity"
 	"tender-service/internal/repository/repoerrs"
@@ -17,55 +18,43 @@ func NewTenderRepository(db *Postgres) *TenderRepository {
 	return &TenderRepository{db: db}
 }
 
-func (r *TenderRepository) GetTenders(ctx context.Context, limit int, offset int, serviceType []string) ([]entity.Tender, error) {
-	getTenders := r.db.Builder.
-		Select("id", "name", "description", "service_type", "status", "organization_id", "version", "created_at").
-		From("tenders").
-		Where("status = ?", "Published").
-		OrderBy("name ASC").
-		Limit(uint64(limit)).
-		Offset(uint64(offset))
-	if len(serviceType) != 0 {
-		getTenders = getTenders.Where("service_type = ANY(?)", serviceType)
-	}
+func (r *TenderRepository) GetTenders(ctx context.Context, page entity.PageRequest, filter entity.TenderFilter) (entity.Page[entity.Tender], error) {
+	ctx, span := tracer.Start(ctx, "TenderRepository.GetTenders")
+	defer span.End()
 
-	query, args, err := getTenders.ToSql()
-	if err != nil {
-		return nil, fmt.Errorf("TenderRepository.GetTenders  - getTenders.ToSql: %v", err)
+	where := squirrel.And{squirrel.Expr("status = ?", "Published")}
+	if len(filter.ServiceType) != 0 {
+		where = append(where, squirrel.Expr("service_type = ANY(?)", filter.ServiceType))
 	}
-
-	rows, err := r.db.Pool.Query(ctx, query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("TenderRepository.GetTenders - r.db.Pool.Query: %v", err)
+	if filter.Query != "" {
+		where = append(where, squirrel.Expr("search_vector @@ (websearch_to_tsquery('russian', ?) || websearch_to_tsquery('english', ?))", filter.Query, filter.Query))
 	}
-	defer rows.Close()
-
-	var tenders []entity.Tender
-	for rows.Next() {
-		var tender entity.Tender
-		err := rows.Scan(
-			&tender.Id,
-			&tender.Name,
-			&tender.Description,
-			&tender.ServiceType,
-			&tender.Status,
-			&tender.OrganizationId,
-			&tender.Version,
-			&tender.CreatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("TenderRepository.GetTenders - rows.Scan: %v", err)
-		}
-		tenders = append(tenders, tender)
+	if filter.OrganizationId != "" {
+		where = append(where, squirrel.Expr("organization_id = ?", filter.OrganizationId))
+	}
+	if filter.CreatedFrom != nil {
+		where = append(where, squirrel.Expr("created_at >= ?", filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		where = append(where, squirrel.Expr("created_at <= ?", filter.CreatedTo))
+	}
+	if filter.MinBudget != nil {
+		where = append(where, squirrel.Expr("budget >= ?", filter.MinBudget))
+	}
+	if filter.MaxBudget != nil {
+		where = append(where, squirrel.Expr("budget <= ?", filter.MaxBudget))
 	}
 
-	return tenders, nil
+	return r.getTenders(ctx, "TenderRepository.GetTenders", page, where, tendersKeyset(filter))
 }
 
 func (r *TenderRepository) CreateTender(ctx context.Context, userId string, tender entity.Tender) (entity.Tender, error) {
+	ctx, span := tracer.Start(ctx, "TenderRepository.CreateTender")
+	defer span.End()
+
 	query, args, err := r.db.Builder.
 		Insert("tenders").
-		Columns("name", "description", "service_type", "status", "organization_id", "version", "creator_id").
+		Columns("name", "description", "service_type", "status", "organization_id", "version", "submission_deadline", "question_deadline", "sealed", "budget", "currency", "creator_id").
 		Values(
 			tender.Name,
 			tender.Description,
@@ -73,6 +62,11 @@ func (r *TenderRepository) CreateTender(ctx context.Context, userId string, tend
 			tender.Status,
 			tender.OrganizationId,
 			tender.Version,
+			tender.SubmissionDeadline,
+			tender.QuestionDeadline,
+			tender.Sealed,
+			tender.Budget,
+			tender.Currency,
 			userId,
 		).
 		Suffix("RETURNING id, created_at").
@@ -82,61 +76,31 @@ func (r *TenderRepository) CreateTender(ctx context.Context, userId string, tend
 		return entity.Tender{}, fmt.Errorf("TenderRepository.CreateTender  - r.db.Builder.ToSql: %v", err)
 	}
 
-	err = r.db.Pool.QueryRow(ctx, query, args...).Scan(
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
 		&tender.Id,
 		&tender.CreatedAt,
 	)
 	if err != nil {
-		return entity.Tender{}, fmt.Errorf("TenderRepository.CreateTender  - r.db.Pool.QueryRow: %v", err)
+		return entity.Tender{}, fmt.Errorf("TenderRepository.CreateTender  - r.db.Conn().QueryRow: %v", err)
 	}
 
 	return tender, nil
 }
 
-func (r *TenderRepository) GetTendersByUserId(ctx context.Context, limit int, offset int, userId string) ([]entity.Tender, error) {
-	query, args, err := r.db.Builder.
-		Select("id", "name", "description", "service_type", "status", "organization_id", "version", "created_at").
-		From("tenders").
-		Where("creator_id = ?", userId).
-		OrderBy("name ASC").
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
-		ToSql()
-
-	if err != nil {
-		return nil, fmt.Errorf("TenderRepository.GetTendersByUserId  - r.db.Builder.ToSql: %v", err)
-	}
-
-	rows, err := r.db.Pool.Query(ctx, query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("TenderRepository.GetTendersByUserId - r.db.Pool.Query: %v", err)
-	}
-	defer rows.Close()
+func (r *TenderRepository) GetTendersByUserId(ctx context.Context, page entity.PageRequest, userId string) (entity.Page[entity.Tender], error) {
+	ctx, span := tracer.Start(ctx, "TenderRepository.GetTendersByUserId")
+	defer span.End()
 
-	var tenders []entity.Tender
-	for rows.Next() {
-		var tender entity.Tender
-		err := rows.Scan(
-			&tender.Id,
-			&tender.Name,
-			&tender.Description,
-			&tender.ServiceType,
-			&tender.Status,
-			&tender.OrganizationId,
-			&tender.Version,
-			&tender.CreatedAt)
-		if err != nil {
-			return nil, fmt.Errorf("TenderRepository.GetTendersByUserId - rows.Scan: %v", err)
-		}
-		tenders = append(tenders, tender)
-	}
-
-	return tenders, nil
+	where := squirrel.Expr("creator_id = ?", userId)
+	return r.getTenders(ctx, "TenderRepository.GetTendersByUserId", page, where, tendersKeyset(entity.TenderFilter{}))
 }
 
 func (r *TenderRepository) GetTenderById(ctx context.Context, id string) (entity.Tender, error) {
+	ctx, span := tracer.Start(ctx, "TenderRepository.GetTenderById")
+	defer span.End()
+
 	query, args, err := r.db.Builder.
-		Select("id", "name", "description", "service_type", "status", "organization_id", "version", "created_at").
+		Select("id", "name", "description", "service_type", "status", "organization_id", "version", "submission_deadline", "question_deadline", "sealed", "budget", "currency", "bids_opened_at", "created_at").
 		From("tenders").
 		Where("id = ?", id).
 		ToSql()
@@ -146,7 +110,7 @@ func (r *TenderRepository) GetTenderById(ctx context.Context, id string) (entity
 	}
 
 	var tender entity.Tender
-	err = r.db.Pool.QueryRow(ctx, query, args...).Scan(
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
 		&tender.Id,
 		&tender.Name,
 		&tender.Description,
@@ -154,24 +118,40 @@ func (r *TenderRepository) GetTenderById(ctx context.Context, id string) (entity
 		&tender.Status,
 		&tender.OrganizationId,
 		&tender.Version,
+		&tender.SubmissionDeadline,
+		&tender.QuestionDeadline,
+		&tender.Sealed,
+		&tender.Budget,
+		&tender.Currency,
+		&tender.BidsOpenedAt,
 		&tender.CreatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entity.Tender{}, repoerrs.ErrNotFound
 		}
-		return entity.Tender{}, fmt.Errorf("TenderRepository.GetTenderById  - r.db.Pool.QueryRow: %v", err)
+		return entity.Tender{}, fmt.Errorf("TenderRepository.GetTenderById  - r.db.Conn().QueryRow: %v", err)
 	}
 
 	return tender, nil
 }
 
 func (r *TenderRepository) UpdateTenderStatus(ctx context.Context, tenderId string, status string) (entity.Tender, error) {
+	ctx, span := tracer.Start(ctx, "TenderRepository.UpdateTenderStatus")
+	defer span.End()
+
+	tx, err := r.db.Conn().Begin(ctx)
+	if err != nil {
+		return entity.Tender{}, fmt.Errorf("TenderRepository.UpdateTenderStatus - r.Pool.Begin: %v", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
 	query, args, err := r.db.Builder.
 		Update("tenders").
 		Set("status", status).
+		Set("updated_at", currentTimestamp).
 		Where("id = ?", tenderId).
-		Suffix("RETURNING id, name, description,  service_type, status, organization_id, version, created_at").
+		Suffix("RETURNING id, name, description,  service_type, status, organization_id, version, submission_deadline, question_deadline, sealed, budget, currency, bids_opened_at, created_at").
 		ToSql()
 
 	if err != nil {
@@ -179,7 +159,7 @@ func (r *TenderRepository) UpdateTenderStatus(ctx context.Context, tenderId stri
 	}
 
 	var tender entity.Tender
-	err = r.db.Pool.QueryRow(ctx, query, args...).Scan(
+	err = tx.QueryRow(ctx, query, args...).Scan(
 		&tender.Id,
 		&tender.Name,
 		&tender.Description,
@@ -187,27 +167,47 @@ func (r *TenderRepository) UpdateTenderStatus(ctx context.Context, tenderId stri
 		&tender.Status,
 		&tender.OrganizationId,
 		&tender.Version,
+		&tender.SubmissionDeadline,
+		&tender.QuestionDeadline,
+		&tender.Sealed,
+		&tender.Budget,
+		&tender.Currency,
+		&tender.BidsOpenedAt,
 		&tender.CreatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entity.Tender{}, repoerrs.ErrNotFound
 		}
-		return entity.Tender{}, fmt.Errorf("TenderRepository.UpdateTenderStatus  - r.db.Pool.QueryRow: %v", err)
+		return entity.Tender{}, fmt.Errorf("TenderRepository.UpdateTenderStatus  - tx.QueryRow: %v", err)
+	}
+
+	if eventType, ok := tenderStatusEvents[status]; ok {
+		if err := insertEvents(ctx, r.db, tx, tenderEvent(eventType, tender)); err != nil {
+			return entity.Tender{}, fmt.Errorf("TenderRepository.UpdateTenderStatus - %v", err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Tender{}, fmt.Errorf("TenderRepository.UpdateTenderStatus - tx.Commit: %v", err)
 	}
 
 	return tender, nil
 }
 
 func (r *TenderRepository) UpdateTender(ctx context.Context, tenderId string, input entity.EditTenderInput) (entity.Tender, error) {
-	tx, err := r.db.Pool.Begin(ctx)
+	ctx, span := tracer.Start(ctx, "TenderRepository.UpdateTender")
+	defer span.End()
+
+	tx, err := r.db.Conn().Begin(ctx)
 	if err != nil {
 		return entity.Tender{}, fmt.Errorf("TenderRepository.UpdateTender - r.Pool.Begin: %v", err)
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
 	getTender, args, err := r.db.Builder.
-		Select("id", "name", "description", "service_type", "status", "organization_id", "version", "created_at", "creator_id").
+		Select("id", "name", "description", "service_type", "status", "organization_id", "version", "submission_deadline", "question_deadline", "sealed", "budget", "currency", "bids_opened_at", "created_at", "creator_id").
 		From("tenders").
 		Where("id = ?", tenderId).
 		ToSql()
@@ -226,6 +226,12 @@ func (r *TenderRepository) UpdateTender(ctx context.Context, tenderId string, in
 		&tender.Status,
 		&tender.OrganizationId,
 		&tender.Version,
+		&tender.SubmissionDeadline,
+		&tender.QuestionDeadline,
+		&tender.Sealed,
+		&tender.Budget,
+		&tender.Currency,
+		&tender.BidsOpenedAt,
 		&tender.CreatedAt,
 		&creatorId,
 	)
@@ -235,10 +241,13 @@ func (r *TenderRepository) UpdateTender(ctx context.Context, tenderId string, in
 		}
 		return entity.Tender{}, fmt.Errorf("TenderRepository.UpdateTender  - tx.QueryRow: %v", err)
 	}
+	if input.Version != nil && *input.Version != tender.Version {
+		return entity.Tender{}, repoerrs.ErrConflict
+	}
 
 	createOldVersionTender, args, err := r.db.Builder.
 		Insert("tenders_old_version").
-		Columns("tender_id", "name", "description", "service_type", "status", "organization_id", "version", "creator_id").
+		Columns("tender_id", "name", "description", "service_type", "status", "organization_id", "version", "submission_deadline", "question_deadline", "sealed", "budget", "currency", "creator_id").
 		Values(
 			tender.Id,
 			tender.Name,
@@ -247,6 +256,11 @@ func (r *TenderRepository) UpdateTender(ctx context.Context, tenderId string, in
 			tender.Status,
 			tender.OrganizationId,
 			tender.Version,
+			tender.SubmissionDeadline,
+			tender.QuestionDeadline,
+			tender.Sealed,
+			tender.Budget,
+			tender.Currency,
 			creatorId,
 		).
 		ToSql()
@@ -262,9 +276,12 @@ func (r *TenderRepository) UpdateTender(ctx context.Context, tenderId string, in
 
 	updateTenderQuery := r.db.Builder.
 		Update("tenders").
-		Where("id = ?", tenderId)
+		Where("id = ?", tenderId).
+		Where("version = ?", tender.Version)
 	tender.Version += 1
-	updateTenderQuery = updateTenderQuery.Set("version", tender.Version)
+	updateTenderQuery = updateTenderQuery.
+		Set("version", tender.Version).
+		Set("updated_at", currentTimestamp)
 	if input.Name != nil {
 		tender.Name = *input.Name
 		updateTenderQuery = updateTenderQuery.Set("name", tender.Name)
@@ -277,16 +294,35 @@ func (r *TenderRepository) UpdateTender(ctx context.Context, tenderId string, in
 		tender.ServiceType = *input.ServiceType
 		updateTenderQuery = updateTenderQuery.Set("service_type", tender.ServiceType)
 	}
+	if input.SubmissionDeadline != nil {
+		tender.SubmissionDeadline = input.SubmissionDeadline
+		updateTenderQuery = updateTenderQuery.Set("submission_deadline", tender.SubmissionDeadline)
+	}
+	if input.QuestionDeadline != nil {
+		tender.QuestionDeadline = input.QuestionDeadline
+		updateTenderQuery = updateTenderQuery.Set("question_deadline", tender.QuestionDeadline)
+	}
+	if input.Budget != nil {
+		tender.Budget = input.Budget
+		updateTenderQuery = updateTenderQuery.Set("budget", tender.Budget)
+	}
+	if input.Currency != nil {
+		tender.Currency = input.Currency
+		updateTenderQuery = updateTenderQuery.Set("currency", tender.Currency)
+	}
 	updateTender, args, err := updateTenderQuery.ToSql()
 
 	if err != nil {
-		return entity.Tender{}, fmt.Errorf("TenderRepository.UpdateTenderStatus  - r.db.Builder.ToSql: %v", err)
+		return entity.Tender{}, fmt.Errorf("TenderRepository.UpdateTender  - r.db.Builder.ToSql: %v", err)
 	}
 
-	_, err = tx.Exec(ctx, updateTender, args...)
+	tag, err := tx.Exec(ctx, updateTender, args...)
 	if err != nil {
 		return entity.Tender{}, fmt.Errorf("TenderRepository.UpdateTender - tx.Exec: %v", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return entity.Tender{}, repoerrs.ErrConflict
+	}
 
 	err = tx.Commit(ctx)
 	if err != nil {
@@ -297,14 +333,17 @@ func (r *TenderRepository) UpdateTender(ctx context.Context, tenderId string, in
 }
 
 func (r *TenderRepository) RollbackTender(ctx context.Context, tenderId string, version int) (entity.Tender, error) {
-	tx, err := r.db.Pool.Begin(ctx)
+	ctx, span := tracer.Start(ctx, "TenderRepository.RollbackTender")
+	defer span.End()
+
+	tx, err := r.db.Conn().Begin(ctx)
 	if err != nil {
 		return entity.Tender{}, fmt.Errorf("TenderRepository.RollbackTender - r.Pool.Begin: %v", err)
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
 	getCurrentTender, args, err := r.db.Builder.
-		Select("id", "name", "description", "service_type", "status", "organization_id", "version", "creator_id").
+		Select("id", "name", "description", "service_type", "status", "organization_id", "version", "submission_deadline", "question_deadline", "sealed", "budget", "currency", "bids_opened_at", "creator_id").
 		From("tenders").
 		Where("id = ?", tenderId).
 		ToSql()
@@ -323,6 +362,12 @@ func (r *TenderRepository) RollbackTender(ctx context.Context, tenderId string,
 		&currentTender.Status,
 		&currentTender.OrganizationId,
 		&currentTender.Version,
+		&currentTender.SubmissionDeadline,
+		&currentTender.QuestionDeadline,
+		&currentTender.Sealed,
+		&currentTender.Budget,
+		&currentTender.Currency,
+		&currentTender.BidsOpenedAt,
 		&creatorId,
 	)
 	if err != nil {
@@ -334,7 +379,7 @@ func (r *TenderRepository) RollbackTender(ctx context.Context, tenderId string,
 
 	createOldVersionTender, args, err := r.db.Builder.
 		Insert("tenders_old_version").
-		Columns("tender_id", "name", "description", "service_type", "status", "organization_id", "version", "creator_id").
+		Columns("tender_id", "name", "description", "service_type", "status", "organization_id", "version", "submission_deadline", "question_deadline", "sealed", "budget", "currency", "creator_id").
 		Values(
 			currentTender.Id,
 			currentTender.Name,
@@ -343,6 +388,11 @@ func (r *TenderRepository) RollbackTender(ctx context.Context, tenderId string,
 			currentTender.Status,
 			currentTender.OrganizationId,
 			currentTender.Version,
+			currentTender.SubmissionDeadline,
+			currentTender.QuestionDeadline,
+			currentTender.Sealed,
+			currentTender.Budget,
+			currentTender.Currency,
 			creatorId,
 		).
 		ToSql()
@@ -357,7 +407,7 @@ func (r *TenderRepository) RollbackTender(ctx context.Context, tenderId string,
 	}
 
 	getTenderReqVersion, args, err := r.db.Builder.
-		Select("id", "name", "description", "service_type", "status", "organization_id", "version", "created_at", "creator_id").
+		Select("id", "name", "description", "service_type", "status", "organization_id", "version", "submission_deadline", "question_deadline", "budget", "currency", "created_at", "creator_id").
 		From("tenders_old_version").
 		Where("tender_id = ?", tenderId).
 		Where("version = ?", version).
@@ -376,6 +426,10 @@ func (r *TenderRepository) RollbackTender(ctx context.Context, tenderId string,
 		&tenderReqVersion.Status,
 		&tenderReqVersion.OrganizationId,
 		&tenderReqVersion.Version,
+		&tenderReqVersion.SubmissionDeadline,
+		&tenderReqVersion.QuestionDeadline,
+		&tenderReqVersion.Budget,
+		&tenderReqVersion.Currency,
 		&tenderReqVersion.CreatedAt,
 		&creatorId,
 	)
@@ -390,6 +444,10 @@ func (r *TenderRepository) RollbackTender(ctx context.Context, tenderId string,
 	currentTender.Name = tenderReqVersion.Name
 	currentTender.Description = tenderReqVersion.Description
 	currentTender.ServiceType = tenderReqVersion.ServiceType
+	currentTender.SubmissionDeadline = tenderReqVersion.SubmissionDeadline
+	currentTender.QuestionDeadline = tenderReqVersion.QuestionDeadline
+	currentTender.Budget = tenderReqVersion.Budget
+	currentTender.Currency = tenderReqVersion.Currency
 
 	rollbackTenderReqVersion, args, err := r.db.Builder.
 		Update("tenders").
@@ -398,6 +456,11 @@ func (r *TenderRepository) RollbackTender(ctx context.Context, tenderId string,
 		Set("name", currentTender.Name).
 		Set("description", currentTender.Description).
 		Set("service_type", currentTender.ServiceType).
+		Set("submission_deadline", currentTender.SubmissionDeadline).
+		Set("question_deadline", currentTender.QuestionDeadline).
+		Set("budget", currentTender.Budget).
+		Set("currency", currentTender.Currency).
+		Set("updated_at", currentTimestamp).
 		ToSql()
 
 	if err != nil {
@@ -416,3 +479,375 @@ func (r *TenderRepository) RollbackTender(ctx context.Context, tenderId string,
 
 	return currentTender, nil
 }
+
+func (r *TenderRepository) LockTender(ctx context.Context, tenderId string) error {
+	ctx, span := tracer.Start(ctx, "TenderRepository.LockTender")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id").
+		From("tenders").
+		Where("id = ?", tenderId).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("TenderRepository.LockTender  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var id string
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repoerrs.ErrNotFound
+		}
+		return fmt.Errorf("TenderRepository.LockTender  - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return nil
+}
+
+func (r *TenderRepository) GetTenderVersions(ctx context.Context, tenderId string) ([]entity.Tender, error) {
+	ctx, span := tracer.Start(ctx, "TenderRepository.GetTenderVersions")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("tender_id", "name", "description", "service_type", "status", "organization_id", "version", "submission_deadline", "question_deadline", "sealed", "budget", "currency", "created_at").
+		Options("DISTINCT ON (version)").
+		From("tenders_old_version").
+		Where("tender_id = ?", tenderId).
+		OrderBy("version ASC", "created_at DESC").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("TenderRepository.GetTenderVersions  - r.db.Builder.ToSql: %v", err)
+	}
+
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("TenderRepository.GetTenderVersions - r.db.Conn().Query: %v", err)
+	}
+	defer rows.Close()
+
+	var tenders []entity.Tender
+	for rows.Next() {
+		var tender entity.Tender
+		err := rows.Scan(
+			&tender.Id,
+			&tender.Name,
+			&tender.Description,
+			&tender.ServiceType,
+			&tender.Status,
+			&tender.OrganizationId,
+			&tender.Version,
+			&tender.SubmissionDeadline,
+			&tender.QuestionDeadline,
+			&tender.Sealed,
+			&tender.Budget,
+			&tender.Currency,
+			&tender.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("TenderRepository.GetTenderVersions - rows.Scan: %v", err)
+		}
+		tenders = append(tenders, tender)
+	}
+
+	return tenders, nil
+}
+
+func (r *TenderRepository) GetTenderVersion(ctx context.Context, tenderId string, version int) (entity.Tender, error) {
+	ctx, span := tracer.Start(ctx, "TenderRepository.GetTenderVersion")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("tender_id", "name", "description", "service_type", "status", "organization_id", "version", "submission_deadline", "question_deadline", "sealed", "budget", "currency", "created_at").
+		From("tenders_old_version").
+		Where("tender_id = ?", tenderId).
+		Where("version = ?", version).
+		OrderBy("created_at DESC").
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return entity.Tender{}, fmt.Errorf("TenderRepository.GetTenderVersion  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var tender entity.Tender
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&tender.Id,
+		&tender.Name,
+		&tender.Description,
+		&tender.ServiceType,
+		&tender.Status,
+		&tender.OrganizationId,
+		&tender.Version,
+		&tender.SubmissionDeadline,
+		&tender.QuestionDeadline,
+		&tender.Sealed,
+		&tender.Budget,
+		&tender.Currency,
+		&tender.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Tender{}, repoerrs.ErrNotFound
+		}
+		return entity.Tender{}, fmt.Errorf("TenderRepository.GetTenderVersion  - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return tender, nil
+}
+
+// CloseExpiredTenders closes published tenders past their submission deadline. Lots still open at that point are
+// cancelled in the same transaction, so a tender without bids or awards does not stay published forever.
+func (r *TenderRepository) CloseExpiredTenders(ctx context.Context) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "TenderRepository.CloseExpiredTenders")
+	defer span.End()
+
+	tx, err := r.db.Conn().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("TenderRepository.CloseExpiredTenders - r.Pool.Begin: %v", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	selectExpired, args, err := expiredTendersQuery(r.db)
+	if err != nil {
+		return nil, fmt.Errorf("TenderRepository.CloseExpiredTenders  - r.db.Builder.ToSql: %v", err)
+	}
+
+	expiredRows, err := tx.Query(ctx, selectExpired, args...)
+	if err != nil {
+		return nil, fmt.Errorf("TenderRepository.CloseExpiredTenders - tx.Query: %v", err)
+	}
+	var expired []string
+	for expiredRows.Next() {
+		var id string
+		if err := expiredRows.Scan(&id); err != nil {
+			expiredRows.Close()
+			return nil, fmt.Errorf("TenderRepository.CloseExpiredTenders - expiredRows.Scan: %v", err)
+		}
+		expired = append(expired, id)
+	}
+	expiredRows.Close()
+	if err := expiredRows.Err(); err != nil {
+		return nil, fmt.Errorf("TenderRepository.CloseExpiredTenders - expiredRows.Err: %v", err)
+	}
+	if len(expired) == 0 {
+		return nil, nil
+	}
+
+	cancelLots, args, err := cancelOpenLotsQuery(r.db, expired...)
+	if err != nil {
+		return nil, fmt.Errorf("TenderRepository.CloseExpiredTenders  - r.db.Builder.ToSql: %v", err)
+	}
+
+	_, err = tx.Exec(ctx, cancelLots, args...)
+	if err != nil {
+		return nil, fmt.Errorf("TenderRepository.CloseExpiredTenders - tx.Exec: %v", err)
+	}
+
+	createOldVersionTenders, args, err := r.db.Builder.
+		Insert("tenders_old_version").
+		Columns("tender_id", "name", "description", "service_type", "status", "organization_id", "version", "submission_deadline", "question_deadline", "sealed", "budget", "currency", "creator_id").
+		Select(r.db.Builder.
+			Select("id", "name", "description", "service_type", "status", "organization_id", "version", "submission_deadline", "question_deadline", "sealed", "budget", "currency", "creator_id").
+			From("tenders").
+			Where(squirrel.Eq{"id": expired})).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("TenderRepository.CloseExpiredTenders  - r.db.Builder.ToSql: %v", err)
+	}
+
+	_, err = tx.Exec(ctx, createOldVersionTenders, args...)
+	if err != nil {
+		return nil, fmt.Errorf("TenderRepository.CloseExpiredTenders - tx.Exec: %v", err)
+	}
+
+	query, args, err := r.db.Builder.
+		Update("tenders").
+		Set("status", "Closed").
+		Set("version", squirrel.Expr("version + 1")).
+		Set("updated_at", currentTimestamp).
+		Where(squirrel.Eq{"id": expired}).
+		Suffix("RETURNING id, status, version").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("TenderRepository.CloseExpiredTenders  - r.db.Builder.ToSql: %v", err)
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("TenderRepository.CloseExpiredTenders - tx.Query: %v", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	var events []entity.Event
+	for rows.Next() {
+		var tender entity.Tender
+		if err := rows.Scan(&tender.Id, &tender.Status, &tender.Version); err != nil {
+			return nil, fmt.Errorf("TenderRepository.CloseExpiredTenders - rows.Scan: %v", err)
+		}
+		ids = append(ids, tender.Id)
+		events = append(events, tenderEvent(entity.EventTenderClosed, tender))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("TenderRepository.CloseExpiredTenders - rows.Err: %v", err)
+	}
+	rows.Close()
+
+	if err := insertEvents(ctx, r.db, tx, events...); err != nil {
+		return nil, fmt.Errorf("TenderRepository.CloseExpiredTenders - %v", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("TenderRepository.CloseExpiredTenders - tx.Commit: %v", err)
+	}
+
+	return ids, nil
+}
+
+func (r *TenderRepository) OpenTenderBids(ctx context.Context, tenderId string) (entity.Tender, error) {
+	ctx, span := tracer.Start(ctx, "TenderRepository.OpenTenderBids")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Update("tenders").
+		Set("bids_opened_at", currentTimestamp).
+		Where("id = ?", tenderId).
+		Where("bids_opened_at IS NULL").
+		Suffix("RETURNING id, name, description, service_type, status, organization_id, version, submission_deadline, question_deadline, sealed, budget, currency, bids_opened_at, created_at").
+		ToSql()
+
+	if err != nil {
+		return entity.Tender{}, fmt.Errorf("TenderRepository.OpenTenderBids  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var tender entity.Tender
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&tender.Id,
+		&tender.Name,
+		&tender.Description,
+		&tender.ServiceType,
+		&tender.Status,
+		&tender.OrganizationId,
+		&tender.Version,
+		&tender.SubmissionDeadline,
+		&tender.QuestionDeadline,
+		&tender.Sealed,
+		&tender.Budget,
+		&tender.Currency,
+		&tender.BidsOpenedAt,
+		&tender.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Tender{}, repoerrs.ErrNotFound
+		}
+		return entity.Tender{}, fmt.Errorf("TenderRepository.OpenTenderBids  - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return tender, nil
+}
+
+func (r *TenderRepository) getTenders(ctx context.Context, op string, page entity.PageRequest, where squirrel.Sqlizer, k keyset) (entity.Page[entity.Tender], error) {
+	getTenders, err := k.paginate(r.db.Builder.
+		Select("id", "name", "description", "service_type", "status", "organization_id", "version", "submission_deadline", "question_deadline", "sealed", "budget", "currency", "bids_opened_at", "created_at").
+		From("tenders").
+		Where(where), page)
+	if err != nil {
+		return entity.Page[entity.Tender]{}, err
+	}
+
+	query, args, err := getTenders.ToSql()
+	if err != nil {
+		return entity.Page[entity.Tender]{}, fmt.Errorf("%s  - getTenders.ToSql: %v", op, err)
+	}
+
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return entity.Page[entity.Tender]{}, fmt.Errorf("%s - r.db.Conn().Query: %v", op, err)
+	}
+	defer rows.Close()
+
+	var tenders []entity.Tender
+	var keys []string
+	for rows.Next() {
+		var tender entity.Tender
+		var key string
+		err := rows.Scan(
+			&tender.Id,
+			&tender.Name,
+			&tender.Description,
+			&tender.ServiceType,
+			&tender.Status,
+			&tender.OrganizationId,
+			&tender.Version,
+			&tender.SubmissionDeadline,
+			&tender.QuestionDeadline,
+			&tender.Sealed,
+			&tender.Budget,
+			&tender.Currency,
+			&tender.BidsOpenedAt,
+			&tender.CreatedAt,
+			&key,
+		)
+		if err != nil {
+			return entity.Page[entity.Tender]{}, fmt.Errorf("%s - rows.Scan: %v", op, err)
+		}
+		tenders = append(tenders, tender)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return entity.Page[entity.Tender]{}, fmt.Errorf("%s - rows.Err: %v", op, err)
+	}
+
+	result := pageOf(k, tenders, keys, func(tender entity.Tender) string { return tender.Id }, page.Limit)
+	if page.Cursor == nil {
+		return result, nil
+	}
+
+	count, args, err := r.db.Builder.
+		Select("COUNT(*)").
+		From("tenders").
+		Where(where).
+		ToSql()
+
+	if err != nil {
+		return entity.Page[entity.Tender]{}, fmt.Errorf("%s  - r.db.Builder.ToSql: %v", op, err)
+	}
+
+	err = r.db.Conn().QueryRow(ctx, count, args...).Scan(&result.Total)
+	if err != nil {
+		return entity.Page[entity.Tender]{}, fmt.Errorf("%s - r.db.Conn().QueryRow: %v", op, err)
+	}
+
+	return result, nil
+}
+
+func tendersKeyset(filter entity.TenderFilter) keyset {
+	k := keyset{column: "name", cast: "text", desc: filter.SortOrder == "desc"}
+	switch filter.SortBy {
+	case "created_at":
+		k.column, k.cast = "created_at", "timestamp"
+	case "deadline":
+		k.column, k.cast = "COALESCE(submission_deadline, 'infinity')", "timestamptz"
+	}
+	k.sort = "tenders:" + k.column + ":" + filter.SortOrder
+	return k
+}
+
+func expiredTendersQuery(db *Postgres) (string, []any, error) {
+	return db.Builder.
+		Select("id").
+		From("tenders").
+		Where("status = ?", "Published").
+		Where("submission_deadline <= CURRENT_TIMESTAMP").
+		OrderBy("id").
+		Suffix("FOR UPDATE").
+		ToSql()
+}
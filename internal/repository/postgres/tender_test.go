@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"github.com/Masterminds/squirrel"
+	"reflect"
+	"testing"
+)
+
+func testPostgres() *Postgres {
+	return &Postgres{Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)}
+}
+
+// An expired tender without bids keeps its default lot open, so selecting expired tenders must not depend on lots:
+// otherwise the scheduler never closes it.
+func TestExpiredTendersQuery(t *testing.T) {
+	query, args, err := expiredTendersQuery(testPostgres())
+	if err != nil {
+		t.Fatalf("expiredTendersQuery() error = %v", err)
+	}
+
+	wantQuery := "SELECT id FROM tenders WHERE status = $1 AND submission_deadline <= CURRENT_TIMESTAMP ORDER BY id FOR UPDATE"
+	if query != wantQuery {
+		t.Errorf("expiredTendersQuery() query = %q, want %q", query, wantQuery)
+	}
+	if want := []any{"Published"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("expiredTendersQuery() args = %v, want %v", args, want)
+	}
+}
+
+func TestCancelOpenLotsQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		tenderIds []string
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			name:      "single tender",
+			tenderIds: []string{"t1"},
+			wantQuery: "UPDATE tender_lots SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE tender_id IN ($2) AND status = $3",
+			wantArgs:  []any{"Cancelled", "t1", "Open"},
+		},
+		{
+			name:      "expired tenders",
+			tenderIds: []string{"t1", "t2"},
+			wantQuery: "UPDATE tender_lots SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE tender_id IN ($2,$3) AND status = $4",
+			wantArgs:  []any{"Cancelled", "t1", "t2", "Open"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := cancelOpenLotsQuery(testPostgres(), tt.tenderIds...)
+			if err != nil {
+				t.Fatalf("cancelOpenLotsQuery() error = %v", err)
+			}
+			if query != tt.wantQuery {
+				t.Errorf("cancelOpenLotsQuery() query = %q, want %q", query, tt.wantQuery)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("cancelOpenLotsQuery() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
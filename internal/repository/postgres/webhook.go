@@ -0,0 +1,322 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"tender-service/internal/entity"
+	"tender-service/internal/repository/repoerrs"
+	"time"
+)
+
+type WebhookRepository struct {
+	db *Postgres
+}
+
+func NewWebhookRepository(db *Postgres) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, webhook entity.Webhook) (entity.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.CreateWebhook")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Insert("webhooks").
+		Columns("organization_id", "url", "secret", "events", "created_by").
+		Values(
+			webhook.OrganizationId,
+			webhook.Url,
+			webhook.Secret,
+			webhook.Events,
+			webhook.CreatedBy,
+		).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	if err != nil {
+		return entity.Webhook{}, fmt.Errorf("WebhookRepository.CreateWebhook  - r.db.Builder.ToSql: %v", err)
+	}
+
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&webhook.Id,
+		&webhook.CreatedAt,
+	)
+	if err != nil {
+		return entity.Webhook{}, fmt.Errorf("WebhookRepository.CreateWebhook - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return webhook, nil
+}
+
+func (r *WebhookRepository) GetWebhooks(ctx context.Context, organizationId string) ([]entity.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.GetWebhooks")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id", "organization_id", "url", "secret", "events", "created_by", "created_at").
+		From("webhooks").
+		Where("organization_id = ?", organizationId).
+		OrderBy("created_at ASC").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository.GetWebhooks  - r.db.Builder.ToSql: %v", err)
+	}
+
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository.GetWebhooks - r.db.Conn().Query: %v", err)
+	}
+	defer rows.Close()
+
+	var webhooks []entity.Webhook
+	for rows.Next() {
+		var webhook entity.Webhook
+		err := rows.Scan(
+			&webhook.Id,
+			&webhook.OrganizationId,
+			&webhook.Url,
+			&webhook.Secret,
+			&webhook.Events,
+			&webhook.CreatedBy,
+			&webhook.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("WebhookRepository.GetWebhooks - rows.Scan: %v", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) GetWebhookById(ctx context.Context, webhookId string) (entity.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.GetWebhookById")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id", "organization_id", "url", "secret", "events", "created_by", "created_at").
+		From("webhooks").
+		Where("id = ?", webhookId).
+		ToSql()
+
+	if err != nil {
+		return entity.Webhook{}, fmt.Errorf("WebhookRepository.GetWebhookById  - r.db.Builder.ToSql: %v", err)
+	}
+
+	var webhook entity.Webhook
+	err = r.db.Conn().QueryRow(ctx, query, args...).Scan(
+		&webhook.Id,
+		&webhook.OrganizationId,
+		&webhook.Url,
+		&webhook.Secret,
+		&webhook.Events,
+		&webhook.CreatedBy,
+		&webhook.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Webhook{}, repoerrs.ErrNotFound
+		}
+		return entity.Webhook{}, fmt.Errorf("WebhookRepository.GetWebhookById - r.db.Conn().QueryRow: %v", err)
+	}
+
+	return webhook, nil
+}
+
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, webhookId string) error {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.DeleteWebhook")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Delete("webhooks").
+		Where("id = ?", webhookId).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("WebhookRepository.DeleteWebhook  - r.db.Builder.ToSql: %v", err)
+	}
+
+	tag, err := r.db.Conn().Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("WebhookRepository.DeleteWebhook - r.db.Conn().Exec: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) EnqueueDeliveries(ctx context.Context, event entity.Event, organizationIds []string, payload []byte) error {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.EnqueueDeliveries")
+	defer span.End()
+
+	subscribers := squirrel.
+		Select("id").
+		Column("CAST(? AS UUID)", event.Id).
+		Column("?", event.Type).
+		Column("CAST(? AS JSONB)", string(payload)).
+		From("webhooks").
+		Where("organization_id = ANY(CAST(? AS UUID[]))", organizationIds).
+		Where("? = ANY(events)", event.Type)
+
+	query, args, err := r.db.Builder.
+		Insert("webhook_deliveries").
+		Columns("webhook_id", "event_id", "event", "payload").
+		Select(subscribers).
+		Suffix("ON CONFLICT (webhook_id, event_id) DO NOTHING").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("WebhookRepository.EnqueueDeliveries  - r.db.Builder.ToSql: %v", err)
+	}
+
+	_, err = r.db.Conn().Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("WebhookRepository.EnqueueDeliveries - r.db.Conn().Exec: %v", err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.PendingDelivery, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.ClaimDueDeliveries")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Update("webhook_deliveries d").
+		Set("next_attempt_at", squirrel.Expr("CURRENT_TIMESTAMP + make_interval(secs => ?)", lease.Seconds())).
+		From("webhooks w").
+		Where("w.id = d.webhook_id").
+		Where(`d.id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'Pending' AND next_attempt_at <= CURRENT_TIMESTAMP
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)`, limit).
+		Suffix("RETURNING d.id, d.webhook_id, d.event_id, d.event, d.payload, d.status::text, d.attempts, " +
+			"d.next_attempt_at, d.last_status_code, d.last_error, d.delivered_at, d.created_at, w.url, w.secret").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository.ClaimDueDeliveries  - r.db.Builder.ToSql: %v", err)
+	}
+
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository.ClaimDueDeliveries - r.db.Conn().Query: %v", err)
+	}
+	defer rows.Close()
+
+	var deliveries []entity.PendingDelivery
+	for rows.Next() {
+		var delivery entity.PendingDelivery
+		err := rows.Scan(
+			&delivery.Id,
+			&delivery.WebhookId,
+			&delivery.EventId,
+			&delivery.Event,
+			&delivery.Payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.LastStatusCode,
+			&delivery.LastError,
+			&delivery.DeliveredAt,
+			&delivery.CreatedAt,
+			&delivery.Url,
+			&delivery.Secret,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("WebhookRepository.ClaimDueDeliveries - rows.Scan: %v", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery entity.WebhookDelivery) error {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.UpdateDelivery")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Update("webhook_deliveries").
+		Set("status", delivery.Status).
+		Set("attempts", delivery.Attempts).
+		Set("next_attempt_at", delivery.NextAttemptAt).
+		Set("last_status_code", delivery.LastStatusCode).
+		Set("last_error", delivery.LastError).
+		Set("delivered_at", delivery.DeliveredAt).
+		Where("id = ?", delivery.Id).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("WebhookRepository.UpdateDelivery  - r.db.Builder.ToSql: %v", err)
+	}
+
+	tag, err := r.db.Conn().Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("WebhookRepository.UpdateDelivery - r.db.Conn().Exec: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, webhookId string, limit int, offset int) ([]entity.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.GetDeliveries")
+	defer span.End()
+
+	query, args, err := r.db.Builder.
+		Select("id", "webhook_id", "event_id", "event", "payload", "status::text", "attempts",
+			"next_attempt_at", "last_status_code", "last_error", "delivered_at", "created_at").
+		From("webhook_deliveries").
+		Where("webhook_id = ?", webhookId).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository.GetDeliveries  - r.db.Builder.ToSql: %v", err)
+	}
+
+	rows, err := r.db.Conn().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository.GetDeliveries - r.db.Conn().Query: %v", err)
+	}
+	defer rows.Close()
+
+	var deliveries []entity.WebhookDelivery
+	for rows.Next() {
+		var delivery entity.WebhookDelivery
+		err := rows.Scan(
+			&delivery.Id,
+			&delivery.WebhookId,
+			&delivery.EventId,
+			&delivery.Event,
+			&delivery.Payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.LastStatusCode,
+			&delivery.LastError,
+			&delivery.DeliveredAt,
+			&delivery.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("WebhookRepository.GetDeliveries - rows.Scan: %v", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
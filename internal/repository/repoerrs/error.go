@@ -3,5 +3,9 @@ package repoerrs
 import "fmt"
 
 var (
-	ErrNotFound = fmt.Errorf("not found")
+	ErrNotFound      = fmt.Errorf("not found")
+	ErrAlreadyExists = fmt.Errorf("already exists")
+	ErrConflict      = fmt.Errorf("version conflict")
+	ErrInvalidCursor = fmt.Errorf("invalid cursor")
+	ErrHasDependents = fmt.Errorf("has dependent records")
 )
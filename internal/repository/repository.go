@@ -2,48 +2,185 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"tender-service/internal/entity"
 	"tender-service/internal/repository/postgres"
+	"time"
 )
 
 type Auth interface {
 	GetUserId(ctx context.Context, username string) (string, error)
-	GetUserOrganizationId(ctx context.Context, userId string) (string, error)
+	GetUserCredentials(ctx context.Context, username string) (string, string, error)
+	GetUserMemberships(ctx context.Context, userId string) ([]entity.OrganizationMember, error)
+	GetOrganizationMembers(ctx context.Context, organizationId string) ([]entity.OrganizationMember, error)
+	UpdateMemberRole(ctx context.Context, organizationId string, userId string, role string) (entity.OrganizationMember, error)
+	LockOrganizationMembers(ctx context.Context, organizationId string) error
 	OrganizationIsExist(ctx context.Context, organizationId string) (bool, error)
 	UserIsExist(ctx context.Context, userId string) (bool, error)
+
+	CreateEmployee(ctx context.Context, employee entity.Employee, passwordHash string) (entity.Employee, error)
+	GetEmployees(ctx context.Context, limit int, offset int) ([]entity.Employee, error)
+	GetEmployeeById(ctx context.Context, id string) (entity.Employee, error)
+	UpdateEmployee(ctx context.Context, id string, input entity.EditEmployeeInput, passwordHash *string) (entity.Employee, error)
+	DeleteEmployee(ctx context.Context, id string) error
+	CreatePasswordReset(ctx context.Context, employeeId string, tokenHash string, createdBy *string, expiresAt time.Time) error
+	ConsumePasswordReset(ctx context.Context, tokenHash string) (string, error)
+
+	CreateOrganization(ctx context.Context, organization entity.Organization, ownerId string) (entity.Organization, error)
+	GetOrganizations(ctx context.Context, limit int, offset int) ([]entity.Organization, error)
+	GetOrganizationById(ctx context.Context, id string) (entity.Organization, error)
+	UpdateOrganization(ctx context.Context, id string, input entity.EditOrganizationInput) (entity.Organization, error)
+	DeleteOrganization(ctx context.Context, id string) error
+	AddResponsible(ctx context.Context, organizationId string, userId string, role string) error
+	RemoveResponsible(ctx context.Context, organizationId string, userId string) error
 }
 
 type Tender interface {
-	GetTenders(ctx context.Context, limit int, offset int, serviceType []string) ([]entity.Tender, error)
+	GetTenders(ctx context.Context, page entity.PageRequest, filter entity.TenderFilter) (entity.Page[entity.Tender], error)
 	CreateTender(ctx context.Context, userId string, tender entity.Tender) (entity.Tender, error)
-	GetTendersByUserId(ctx context.Context, limit int, offset int, userId string) ([]entity.Tender, error)
+	GetTendersByUserId(ctx context.Context, page entity.PageRequest, userId string) (entity.Page[entity.Tender], error)
 	GetTenderById(ctx context.Context, id string) (entity.Tender, error)
 	UpdateTenderStatus(ctx context.Context, tenderId string, status string) (entity.Tender, error)
 	UpdateTender(ctx context.Context, tenderId string, input entity.EditTenderInput) (entity.Tender, error)
 	RollbackTender(ctx context.Context, tenderId string, version int) (entity.Tender, error)
+	LockTender(ctx context.Context, tenderId string) error
+	GetTenderVersions(ctx context.Context, tenderId string) ([]entity.Tender, error)
+	GetTenderVersion(ctx context.Context, tenderId string, version int) (entity.Tender, error)
+	CloseExpiredTenders(ctx context.Context) ([]string, error)
+	OpenTenderBids(ctx context.Context, tenderId string) (entity.Tender, error)
 }
 
 type Bid interface {
 	CreateBid(ctx context.Context, bid entity.Bid) (entity.Bid, error)
-	GetBidsByUserId(ctx context.Context, limit int, offset int, userId string) ([]entity.Bid, error)
-	GetBidsForTender(ctx context.Context, tenderId string, limit int, offset int) ([]entity.Bid, error)
+	GetBidsByUserId(ctx context.Context, page entity.PageRequest, userId string) (entity.Page[entity.Bid], error)
+	GetBidsForTender(ctx context.Context, tenderId string, page entity.PageRequest, filter entity.BidFilter) (entity.Page[entity.Bid], error)
+	GetPublishedBidsForTender(ctx context.Context, tenderId string) ([]entity.Bid, error)
+	GetActiveBidsForTender(ctx context.Context, tenderId string) ([]entity.Bid, error)
+	CountBidsForTender(ctx context.Context, tenderId string, lotId string) (int, error)
 	GetBidById(ctx context.Context, id string) (entity.Bid, error)
 	UpdateBidStatus(ctx context.Context, bidId string, status string) (entity.Bid, error)
 	UpdateBid(ctx context.Context, bidId string, input entity.EditBidInput) (entity.Bid, error)
 	RollbackBid(ctx context.Context, bidId string, version int) (entity.Bid, error)
 	SubmitBidDecision(ctx context.Context, bidId string, decision string) (entity.Bid, error)
+	CreateBidDecision(ctx context.Context, decision entity.BidDecision) (entity.BidDecision, error)
+	GetBidDecisions(ctx context.Context, bidId string) ([]entity.BidDecision, error)
+	GetBidVersions(ctx context.Context, bidId string) ([]entity.Bid, error)
+	GetBidVersion(ctx context.Context, bidId string, version int) (entity.Bid, error)
+}
+
+type Lot interface {
+	CreateLots(ctx context.Context, tenderId string, lots []entity.Lot) ([]entity.Lot, error)
+	GetLots(ctx context.Context, tenderId string) ([]entity.Lot, error)
+	GetLotById(ctx context.Context, lotId string) (entity.Lot, error)
+	CloseLot(ctx context.Context, lotId string, status string, awardedBidId *string) (entity.Lot, error)
+	CountOpenLots(ctx context.Context, tenderId string) (int, error)
+	CancelOpenLots(ctx context.Context, tenderId string) (int, error)
+	CreateBidLots(ctx context.Context, bidLots []entity.BidLot) error
+	GetBidLots(ctx context.Context, bidId string) ([]entity.BidLot, error)
+	UpdateBidLotPrice(ctx context.Context, bidLot entity.BidLot) error
+	SubmitBidLotDecision(ctx context.Context, bidId string, lotId string, decision string) error
+}
+
+type Attachment interface {
+	CreateAttachment(ctx context.Context, attachment entity.Attachment) (entity.Attachment, error)
+	GetAttachments(ctx context.Context, owner entity.AttachmentOwner, version int) ([]entity.Attachment, error)
+	GetAttachmentById(ctx context.Context, id string) (entity.Attachment, error)
+	RemoveAttachment(ctx context.Context, id string, version int) error
+	RestoreAttachments(ctx context.Context, owner entity.AttachmentOwner, version int, newVersion int) error
+}
+
+type Question interface {
+	CreateQuestion(ctx context.Context, question entity.Question) (entity.Question, error)
+	GetQuestions(ctx context.Context, tenderId string) ([]entity.Question, error)
+	GetQuestionById(ctx context.Context, questionId string) (entity.Question, error)
+	AnswerQuestion(ctx context.Context, questionId string, answer string, visibility string, userId string) (entity.Question, error)
+}
+
+type Evaluation interface {
+	GetCriteria(ctx context.Context, tenderId string) ([]entity.Criterion, error)
+	ReplaceCriteria(ctx context.Context, tenderId string, criteria []entity.Criterion) ([]entity.Criterion, error)
+	TenderHasScores(ctx context.Context, tenderId string) (bool, error)
+	SaveBidScore(ctx context.Context, score entity.BidScore) (entity.BidScore, error)
+	GetBidScores(ctx context.Context, bidId string) ([]entity.BidScore, error)
+	GetBidScoreHistory(ctx context.Context, bidId string) ([]entity.BidScore, error)
+	GetTenderScores(ctx context.Context, tenderId string) ([]entity.BidScore, error)
+}
+
+type Webhook interface {
+	CreateWebhook(ctx context.Context, webhook entity.Webhook) (entity.Webhook, error)
+	GetWebhooks(ctx context.Context, organizationId string) ([]entity.Webhook, error)
+	GetWebhookById(ctx context.Context, webhookId string) (entity.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookId string) error
+	EnqueueDeliveries(ctx context.Context, event entity.Event, organizationIds []string, payload []byte) error
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.PendingDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery entity.WebhookDelivery) error
+	GetDeliveries(ctx context.Context, webhookId string, limit int, offset int) ([]entity.WebhookDelivery, error)
+}
+
+type Outbox interface {
+	TryLockRelay(ctx context.Context) (bool, error)
+	GetUnpublishedEvents(ctx context.Context, limit int) ([]entity.Event, error)
+	MarkPublished(ctx context.Context, sequences []int64) error
+}
+
+type Event interface {
+	NotifyEvent(ctx context.Context, event entity.Event) error
+	ListenEvents(ctx context.Context, handle func(event entity.Event)) error
+}
+
+type Health interface {
+	Ping(ctx context.Context) error
+	GetMigrationVersion(ctx context.Context) (int, bool, error)
 }
 
 type Repository struct {
-	Auth   Auth
-	Tender Tender
-	Bid    Bid
+	Auth       Auth
+	Tender     Tender
+	Bid        Bid
+	Lot        Lot
+	Attachment Attachment
+	Question   Question
+	Evaluation Evaluation
+	Webhook    Webhook
+	Outbox     Outbox
+	Event      Event
+	Health     Health
+
+	db *postgres.Postgres
 }
 
 func NewRepository(db *postgres.Postgres) *Repository {
 	return &Repository{
-		Auth:   postgres.NewAuthRepository(db),
-		Tender: postgres.NewTenderRepository(db),
-		Bid:    postgres.NewBidRepository(db),
+		Auth:       postgres.NewAuthRepository(db),
+		Tender:     postgres.NewTenderRepository(db),
+		Bid:        postgres.NewBidRepository(db),
+		Lot:        postgres.NewLotRepository(db),
+		Attachment: postgres.NewAttachmentRepository(db),
+		Question:   postgres.NewQuestionRepository(db),
+		Evaluation: postgres.NewEvaluationRepository(db),
+		Webhook:    postgres.NewWebhookRepository(db),
+		Outbox:     postgres.NewOutboxRepository(db),
+		Event:      postgres.NewEventRepository(db),
+		Health:     postgres.NewHealthRepository(db),
+		db:         db,
 	}
 }
+
+func (r *Repository) WithTx(ctx context.Context, fn func(repos *Repository) error) error {
+	tx, err := r.db.Conn().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Repository.WithTx - r.db.Conn().Begin: %v", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(NewRepository(r.db.WithTx(tx))); err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("Repository.WithTx - tx.Commit: %v", err)
+	}
+
+	return nil
+}
@@ -16,6 +16,8 @@ const (
 type Server struct {
 	httpServer      *http.Server
 	shutdownTimeout time.Duration
+	drainDelay      time.Duration
+	onDrain         []func()
 	notify          chan error
 }
 
@@ -52,7 +54,20 @@ func (s *Server) Notify() <-chan error {
 	return s.notify
 }
 
+func (s *Server) RegisterOnShutdown(f func()) {
+	s.httpServer.RegisterOnShutdown(f)
+}
+
+func (s *Server) RegisterOnDrain(f func()) {
+	s.onDrain = append(s.onDrain, f)
+}
+
 func (s *Server) Shutdown() error {
+	for _, f := range s.onDrain {
+		f()
+	}
+	time.Sleep(s.drainDelay)
+
 	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 	defer cancel()
 
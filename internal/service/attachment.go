@@ -0,0 +1,403 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/gabriel-vasile/mimetype"
+	"hash"
+	"io"
+	"path"
+	"tender-service/internal/entity"
+	"tender-service/internal/logger"
+	"tender-service/internal/repository"
+	"tender-service/internal/repository/repoerrs"
+	"tender-service/internal/storage"
+)
+
+const mimeSniffSize = 3072
+
+type AttachmentService struct {
+	repo         *repository.Repository
+	store        storage.BlobStore
+	maxSize      int64
+	allowedTypes []string
+}
+
+func NewAttachmentService(repo *repository.Repository, store storage.BlobStore, maxSize int64, allowedTypes []string) *AttachmentService {
+	return &AttachmentService{
+		repo:         repo,
+		store:        store,
+		maxSize:      maxSize,
+		allowedTypes: allowedTypes,
+	}
+}
+
+func (s *AttachmentService) GetAttachments(ctx context.Context, owner entity.AttachmentOwner, userId string, version *int) ([]entity.Attachment, error) {
+	ctx, span := tracer.Start(ctx, "AttachmentService.GetAttachments")
+	defer span.End()
+
+	current, err := s.checkView(ctx, owner, userId)
+	if err != nil {
+		return nil, err
+	}
+	if version == nil {
+		version = &current
+	}
+	if *version < 1 || *version > current {
+		return nil, ErrVersionNotFound
+	}
+
+	attachments, err := s.repo.Attachment.GetAttachments(ctx, owner, *version)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("AttachmentService.GetAttachments: cannot get attachments: %v", err)
+		return nil, ErrCannotGetAttachment
+	}
+
+	return attachments, nil
+}
+func (s *AttachmentService) UploadAttachment(ctx context.Context, owner entity.AttachmentOwner, userId string, expectedVersion *int, upload entity.AttachmentUpload) (entity.Attachment, error) {
+	ctx, span := tracer.Start(ctx, "AttachmentService.UploadAttachment")
+	defer span.End()
+
+	if _, err := s.checkEdit(ctx, s.repo, owner, userId); err != nil {
+		return entity.Attachment{}, err
+	}
+	if upload.Size > s.maxSize {
+		return entity.Attachment{}, ErrAttachmentTooLarge
+	}
+
+	head := make([]byte, mimeSniffSize)
+	n, err := io.ReadFull(upload.Content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		logger.FromContext(ctx).Errorf("AttachmentService.UploadAttachment: cannot read upload: %v", err)
+		return entity.Attachment{}, ErrCannotUploadAttachment
+	}
+	head = head[:n]
+	contentType := mimetype.Detect(head)
+	if !s.typeAllowed(contentType) {
+		return entity.Attachment{}, ErrAttachmentTypeNotAllowed
+	}
+
+	key, err := attachmentKey(owner)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("AttachmentService.UploadAttachment: cannot generate storage key: %v", err)
+		return entity.Attachment{}, ErrCannotUploadAttachment
+	}
+
+	content := &countingReader{
+		reader: io.LimitReader(io.MultiReader(bytes.NewReader(head), upload.Content), s.maxSize+1),
+		hash:   sha256.New(),
+	}
+	if err := s.store.Put(ctx, key, content, upload.Size, contentType.String()); err != nil {
+		logger.FromContext(ctx).Errorf("AttachmentService.UploadAttachment: cannot store blob: %v", err)
+		return entity.Attachment{}, ErrCannotUploadAttachment
+	}
+	if content.size > s.maxSize {
+		s.deleteBlob(ctx, key)
+		return entity.Attachment{}, ErrAttachmentTooLarge
+	}
+
+	attachment := entity.Attachment{
+		FileName:    path.Base(upload.FileName),
+		ContentType: contentType.String(),
+		Size:        content.size,
+		Checksum:    hex.EncodeToString(content.hash.Sum(nil)),
+		StorageKey:  key,
+		UploadedBy:  &userId,
+	}
+	err = inTx(ctx, s.repo, "AttachmentService.UploadAttachment", ErrCannotUploadAttachment, func(repos *repository.Repository) error {
+		version, err := s.nextVersion(ctx, repos, owner, userId, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		switch owner.Type {
+		case entity.AttachmentOwnerTender:
+			attachment.TenderId = &owner.Id
+		case entity.AttachmentOwnerBid:
+			attachment.BidId = &owner.Id
+		}
+		attachment.AddedInVersion = version
+
+		attachment, err = repos.Attachment.CreateAttachment(ctx, attachment)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("AttachmentService.UploadAttachment: cannot create attachment: %v", err)
+			return ErrCannotUploadAttachment
+		}
+		return nil
+	})
+	if err != nil {
+		s.deleteBlob(ctx, key)
+		return entity.Attachment{}, err
+	}
+
+	return attachment, nil
+}
+func (s *AttachmentService) DownloadAttachment(ctx context.Context, owner entity.AttachmentOwner, attachmentId string, userId string) (entity.AttachmentContent, error) {
+	ctx, span := tracer.Start(ctx, "AttachmentService.DownloadAttachment")
+	defer span.End()
+
+	if _, err := s.checkView(ctx, owner, userId); err != nil {
+		return entity.AttachmentContent{}, err
+	}
+
+	attachment, err := s.getOwnedAttachment(ctx, s.repo, owner, attachmentId)
+	if err != nil {
+		return entity.AttachmentContent{}, err
+	}
+
+	content, err := s.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			logger.FromContext(ctx).Errorf("AttachmentService.DownloadAttachment: blob %s is missing", attachment.StorageKey)
+			return entity.AttachmentContent{}, ErrAttachmentNotFound
+		}
+		logger.FromContext(ctx).Errorf("AttachmentService.DownloadAttachment: cannot get blob: %v", err)
+		return entity.AttachmentContent{}, ErrCannotGetAttachment
+	}
+
+	return entity.AttachmentContent{Attachment: attachment, Content: content}, nil
+}
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, owner entity.AttachmentOwner, attachmentId string, userId string, expectedVersion *int) error {
+	ctx, span := tracer.Start(ctx, "AttachmentService.DeleteAttachment")
+	defer span.End()
+
+	return inTx(ctx, s.repo, "AttachmentService.DeleteAttachment", ErrCannotDeleteAttachment, func(repos *repository.Repository) error {
+		attachment, err := s.getOwnedAttachment(ctx, repos, owner, attachmentId)
+		if err != nil {
+			return err
+		}
+		if attachment.RemovedInVersion != nil {
+			return ErrAttachmentNotFound
+		}
+
+		version, err := s.nextVersion(ctx, repos, owner, userId, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		err = repos.Attachment.RemoveAttachment(ctx, attachmentId, version)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrAttachmentNotFound
+			}
+			logger.FromContext(ctx).Errorf("AttachmentService.DeleteAttachment: cannot remove attachment: %v", err)
+			return ErrCannotDeleteAttachment
+		}
+		return nil
+	})
+}
+
+func (s *AttachmentService) checkView(ctx context.Context, owner entity.AttachmentOwner, userId string) (int, error) {
+	switch owner.Type {
+	case entity.AttachmentOwnerTender:
+		tender, err := s.getTender(ctx, s.repo, owner.Id)
+		if err != nil {
+			return 0, err
+		}
+		if tender.Status != "Published" {
+			if err := checkPermission(ctx, s.repo, userId, tender.OrganizationId, entity.PermissionTenderView); err != nil {
+				return 0, err
+			}
+		}
+		return tender.Version, nil
+	case entity.AttachmentOwnerBid:
+		bid, err := s.getBid(ctx, s.repo, owner.Id)
+		if err != nil {
+			return 0, err
+		}
+		if checkBidAuthor(ctx, s.repo, bid, userId) == nil {
+			return bid.Version, nil
+		}
+		tender, err := s.getTender(ctx, s.repo, bid.TenderId)
+		if err != nil {
+			return 0, err
+		}
+		if err := checkPermission(ctx, s.repo, userId, tender.OrganizationId, entity.PermissionTenderView); err != nil {
+			return 0, err
+		}
+		if bid.Status == "Created" {
+			return 0, ErrNotEnoughPermissions
+		}
+		if bidsSealed(tender) {
+			return 0, ErrBidsSealed
+		}
+		return bid.Version, nil
+	}
+	return 0, fmt.Errorf("unknown attachment owner %q", owner.Type)
+}
+
+func (s *AttachmentService) checkEdit(ctx context.Context, repos *repository.Repository, owner entity.AttachmentOwner, userId string) (int, error) {
+	switch owner.Type {
+	case entity.AttachmentOwnerTender:
+		tender, err := s.getTender(ctx, repos, owner.Id)
+		if err != nil {
+			return 0, err
+		}
+		if tender.Status == "Closed" {
+			return 0, tenderClosedError(tender.Id)
+		}
+		if err := checkPermission(ctx, repos, userId, tender.OrganizationId, entity.PermissionTenderEdit); err != nil {
+			return 0, err
+		}
+		return tender.Version, nil
+	case entity.AttachmentOwnerBid:
+		bid, err := s.getBid(ctx, repos, owner.Id)
+		if err != nil {
+			return 0, err
+		}
+		if bid.Status == "Canceled" {
+			return 0, bidCanceledError(bid.Id)
+		}
+		if err := checkBidAuthor(ctx, repos, bid, userId); err != nil {
+			return 0, err
+		}
+		if err := checkSubmissionOpen(ctx, repos, bid.TenderId); err != nil {
+			return 0, err
+		}
+		return bid.Version, nil
+	}
+	return 0, fmt.Errorf("unknown attachment owner %q", owner.Type)
+}
+
+func (s *AttachmentService) nextVersion(ctx context.Context, repos *repository.Repository, owner entity.AttachmentOwner, userId string, expectedVersion *int) (int, error) {
+	if owner.Type == entity.AttachmentOwnerTender {
+		if err := repos.Tender.LockTender(ctx, owner.Id); err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return 0, ErrTenderNotFound
+			}
+			logger.FromContext(ctx).Errorf("AttachmentService.nextVersion: cannot lock tender: %v", err)
+			return 0, ErrCannotUpdateTender
+		}
+	}
+
+	current, err := s.checkEdit(ctx, repos, owner, userId)
+	if err != nil {
+		return 0, err
+	}
+	if expectedVersion != nil && *expectedVersion != current {
+		return 0, &VersionConflictError{CurrentVersion: current}
+	}
+
+	switch owner.Type {
+	case entity.AttachmentOwnerTender:
+		tender, err := repos.Tender.UpdateTender(ctx, owner.Id, entity.EditTenderInput{Version: &current})
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrConflict) {
+				return 0, ErrVersionConflict
+			}
+			logger.FromContext(ctx).Errorf("AttachmentService.nextVersion: cannot update tender: %v", err)
+			return 0, ErrCannotUpdateTender
+		}
+		return tender.Version, nil
+	case entity.AttachmentOwnerBid:
+		bid, err := repos.Bid.UpdateBid(ctx, owner.Id, entity.EditBidInput{Version: &current})
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrConflict) {
+				return 0, ErrVersionConflict
+			}
+			logger.FromContext(ctx).Errorf("AttachmentService.nextVersion: cannot update bid: %v", err)
+			return 0, ErrCannotUpdateBid
+		}
+		return bid.Version, nil
+	}
+	return 0, fmt.Errorf("unknown attachment owner %q", owner.Type)
+}
+
+func (s *AttachmentService) getOwnedAttachment(ctx context.Context, repos *repository.Repository, owner entity.AttachmentOwner, attachmentId string) (entity.Attachment, error) {
+	attachment, err := repos.Attachment.GetAttachmentById(ctx, attachmentId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.Attachment{}, ErrAttachmentNotFound
+		}
+		logger.FromContext(ctx).Errorf("AttachmentService.getOwnedAttachment: cannot get attachment: %v", err)
+		return entity.Attachment{}, ErrCannotGetAttachment
+	}
+
+	ownerId := attachment.TenderId
+	if owner.Type == entity.AttachmentOwnerBid {
+		ownerId = attachment.BidId
+	}
+	if ownerId == nil || *ownerId != owner.Id {
+		return entity.Attachment{}, ErrAttachmentNotFound
+	}
+
+	return attachment, nil
+}
+
+func (s *AttachmentService) getTender(ctx context.Context, repos *repository.Repository, tenderId string) (entity.Tender, error) {
+	tender, err := repos.Tender.GetTenderById(ctx, tenderId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.Tender{}, ErrTenderNotFound
+		}
+		logger.FromContext(ctx).Errorf("AttachmentService.getTender: cannot get tender: %v", err)
+		return entity.Tender{}, ErrCannotGetTender
+	}
+
+	return tender, nil
+}
+
+func (s *AttachmentService) getBid(ctx context.Context, repos *repository.Repository, bidId string) (entity.Bid, error) {
+	bid, err := repos.Bid.GetBidById(ctx, bidId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.Bid{}, ErrBidNotFound
+		}
+		logger.FromContext(ctx).Errorf("AttachmentService.getBid: cannot get bid: %v", err)
+		return entity.Bid{}, ErrCannotGetBid
+	}
+
+	return bid, nil
+}
+
+func (s *AttachmentService) typeAllowed(contentType *mimetype.MIME) bool {
+	for _, allowed := range s.allowedTypes {
+		if contentType.Is(allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *AttachmentService) deleteBlob(ctx context.Context, key string) {
+	if err := s.store.Delete(context.WithoutCancel(ctx), key); err != nil {
+		logger.FromContext(ctx).Errorf("AttachmentService.deleteBlob: cannot delete blob %s: %v", key, err)
+	}
+}
+
+func checkBidAuthor(ctx context.Context, repo *repository.Repository, bid entity.Bid, userId string) error {
+	if bid.AuthorType == "User" {
+		if bid.AuthorId != userId {
+			return ErrNotEnoughPermissions
+		}
+		return nil
+	}
+	return checkSharedOrganization(ctx, repo, userId, bid.AuthorId)
+}
+
+func attachmentKey(owner entity.AttachmentOwner) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return path.Join(owner.Type+"s", owner.Id, hex.EncodeToString(id)), nil
+}
+
+type countingReader struct {
+	reader io.Reader
+	hash   hash.Hash
+	size   int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.size += int64(n)
+	r.hash.Write(p[:n])
+	return n, err
+}
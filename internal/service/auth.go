@@ -3,53 +3,217 @@ package service
 import (
 	"context"
 	"errors"
-	"github.com/sirupsen/logrus"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+	"slices"
+	"tender-service/internal/entity"
+	"tender-service/internal/logger"
 	"tender-service/internal/repository"
 	"tender-service/internal/repository/repoerrs"
+	"time"
 )
 
+type TokenClaims struct {
+	jwt.RegisteredClaims
+	UserId string `json:"userId"`
+}
+
 type AuthService struct {
-	repo *repository.Repository
+	repo     *repository.Repository
+	signKey  []byte
+	tokenTTL time.Duration
+}
+
+func NewAuthService(repo *repository.Repository, signKey string, tokenTTL time.Duration) *AuthService {
+	return &AuthService{
+		repo:     repo,
+		signKey:  []byte(signKey),
+		tokenTTL: tokenTTL,
+	}
 }
 
-func NewAuthService(repo *repository.Repository) *AuthService {
-	return &AuthService{repo: repo}
+func (s *AuthService) GenerateToken(ctx context.Context, input entity.SignInInput) (string, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.GenerateToken")
+	defer span.End()
+
+	userId, passwordHash, err := s.repo.Auth.GetUserCredentials(ctx, input.Username)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return "", ErrInvalidCredentials
+		}
+		logger.FromContext(ctx).Errorf("AuthService.GenerateToken: cannot get user credentials: %v", err)
+		return "", ErrCannotGetUserId
+	}
+	if passwordHash == "" {
+		return "", ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(input.Password)); err != nil {
+		return "", ErrInvalidCredentials
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &TokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userId,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.tokenTTL)),
+		},
+		UserId: userId,
+	})
+
+	signedToken, err := token.SignedString(s.signKey)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("AuthService.GenerateToken: cannot sign token: %v", err)
+		return "", ErrCannotSignToken
+	}
+
+	return signedToken, nil
 }
+func (s *AuthService) ParseToken(ctx context.Context, accessToken string) (string, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.ParseToken")
+	defer span.End()
 
+	token, err := jwt.ParseWithClaims(accessToken, &TokenClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return s.signKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return "", ErrTokenExpired
+		}
+		return "", ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*TokenClaims)
+	if !ok || claims.UserId == "" {
+		return "", ErrInvalidToken
+	}
+
+	ok, err = s.repo.Auth.UserIsExist(ctx, claims.UserId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return "", ErrUserNotFound
+		}
+		logger.FromContext(ctx).Errorf("AuthService.ParseToken: cannot check existence of the user: %v", err)
+		return "", ErrCannotGetUserId
+	}
+	if !ok {
+		return "", ErrUserNotFound
+	}
+
+	return claims.UserId, nil
+}
 func (s *AuthService) GetUserId(ctx context.Context, username string) (string, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.GetUserId")
+	defer span.End()
+
 	id, err := s.repo.Auth.GetUserId(ctx, username)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
 			return "", ErrUserNotFound
 		}
-		logrus.Errorf("AuthService.GetUserId: cannot get user id: %v", err)
+		logger.FromContext(ctx).Errorf("AuthService.GetUserId: cannot get user id: %v", err)
 		return "", ErrCannotGetUserId
 	}
 
 	return id, nil
 }
 func (s *AuthService) CheckResponsibility(ctx context.Context, userId string, organizationId string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.CheckResponsibility")
+	defer span.End()
+
 	ok, err := s.repo.Auth.OrganizationIsExist(ctx, organizationId)
 	if !ok {
 		if errors.Is(err, repoerrs.ErrNotFound) {
 			return false, ErrOrganizationNotFound
 		}
-		logrus.Errorf("AuthService.CheckResponsibility: cannot check existence of the organization: %v", err)
+		logger.FromContext(ctx).Errorf("AuthService.CheckResponsibility: cannot check existence of the organization: %v", err)
 		return false, ErrOrganizationNotFound
 	}
 
-	userOrganizationId, err := s.repo.Auth.GetUserOrganizationId(ctx, userId)
+	members, err := userMemberships(ctx, s.repo, userId)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
 			return false, ErrUserOrganizationNotFound
 		}
-		logrus.Errorf("AuthService.GetUserId: cannot get user organization id: %v", err)
+		logger.FromContext(ctx).Errorf("AuthService.CheckResponsibility: cannot get user memberships: %v", err)
 		return false, ErrUserOrganizationNotFound
 	}
 
-	if userOrganizationId != organizationId {
+	if !slices.Contains(organizationIds(members), organizationId) {
 		return false, ErrNotEnoughPermissions
 	}
 
 	return true, nil
 }
+
+func userMemberships(ctx context.Context, repo *repository.Repository, userId string) ([]entity.OrganizationMember, error) {
+	members, err := repo.Auth.GetUserMemberships(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationId, ok := OrganizationIdFromContext(ctx)
+	if !ok {
+		return members, nil
+	}
+	for _, member := range members {
+		if member.OrganizationId == organizationId {
+			return []entity.OrganizationMember{member}, nil
+		}
+	}
+
+	return nil, repoerrs.ErrNotFound
+}
+
+func organizationIds(members []entity.OrganizationMember) []string {
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.OrganizationId)
+	}
+	return ids
+}
+
+func checkPermission(ctx context.Context, repo *repository.Repository, userId string, organizationId string, permission string) error {
+	members, err := userMemberships(ctx, repo, userId)
+	if err != nil {
+		if !errors.Is(err, repoerrs.ErrNotFound) {
+			logger.FromContext(ctx).Errorf("service.checkPermission: cannot get user memberships: %v", err)
+		}
+		return ErrNotEnoughPermissions
+	}
+
+	for _, member := range members {
+		if member.OrganizationId == organizationId && slices.Contains(rolePermissions[member.Role], permission) {
+			return nil
+		}
+	}
+
+	return ErrNotEnoughPermissions
+}
+
+func checkSharedOrganization(ctx context.Context, repo *repository.Repository, userId string, authorId string) error {
+	members, err := userMemberships(ctx, repo, userId)
+	if err != nil {
+		if !errors.Is(err, repoerrs.ErrNotFound) {
+			logger.FromContext(ctx).Errorf("service.checkSharedOrganization: cannot get user memberships: %v", err)
+		}
+		return ErrNotEnoughPermissions
+	}
+
+	authorMembers, err := repo.Auth.GetUserMemberships(ctx, authorId)
+	if err != nil {
+		if !errors.Is(err, repoerrs.ErrNotFound) {
+			logger.FromContext(ctx).Errorf("service.checkSharedOrganization: cannot get author memberships: %v", err)
+		}
+		return ErrNotEnoughPermissions
+	}
+
+	ids := organizationIds(members)
+	for _, member := range authorMembers {
+		if slices.Contains(ids, member.OrganizationId) {
+			return nil
+		}
+	}
+
+	return ErrNotEnoughPermissions
+}
@@ -3,32 +3,49 @@ package service
 import (
 	"context"
 	"errors"
-	"fmt"
-	"github.com/sirupsen/logrus"
+	"slices"
 	"tender-service/internal/entity"
+	"tender-service/internal/logger"
+	"tender-service/internal/metrics"
 	"tender-service/internal/repository"
 	"tender-service/internal/repository/repoerrs"
 )
 
 type BidService struct {
-	repo *repository.Repository
+	repo           *repository.Repository
+	metrics        *metrics.Metrics
+	approvalQuorum int
 }
 
-func NewBidService(repo *repository.Repository) *BidService {
-	return &BidService{repo: repo}
+func NewBidService(repo *repository.Repository, metrics *metrics.Metrics, approvalQuorum int) *BidService {
+	return &BidService{
+		repo:           repo,
+		metrics:        metrics,
+		approvalQuorum: approvalQuorum,
+	}
 }
 
 func (s *BidService) CreateBid(ctx context.Context, input entity.CreateBidInput) (entity.Bid, error) {
+	ctx, span := tracer.Start(ctx, "BidService.CreateBid")
+	defer span.End()
+	ctx = logger.WithField(ctx, logger.TenderIdField, input.TenderId)
+
 	tender, err := s.repo.Tender.GetTenderById(ctx, input.TenderId)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
 			return entity.Bid{}, ErrTenderNotFound
 		}
-		logrus.Errorf("TenderService.UpdateTenderStatus: cannot get tender: %v", err)
+		logger.FromContext(ctx).Errorf("BidService.CreateBid: cannot get tender: %v", err)
 		return entity.Bid{}, ErrCannotGetTender
 	}
 	if tender.Status == "Closed" {
-		return entity.Bid{}, fmt.Errorf("cannot edit closed tender")
+		return entity.Bid{}, tenderClosedError(tender.Id)
+	}
+	if deadlinePassed(tender) {
+		return entity.Bid{}, ErrSubmissionDeadlinePassed
+	}
+	if err := checkBidPrice(tender, input.Price, &input.Currency); err != nil {
+		return entity.Bid{}, err
 	}
 
 	ok, err := s.repo.Auth.UserIsExist(ctx, input.AuthorId)
@@ -36,21 +53,26 @@ func (s *BidService) CreateBid(ctx context.Context, input entity.CreateBidInput)
 		if errors.Is(err, repoerrs.ErrNotFound) {
 			return entity.Bid{}, ErrUserNotFound
 		}
-		logrus.Errorf("BidService.CreateBid: cannot check existence of the user: %v", err)
+		logger.FromContext(ctx).Errorf("BidService.CreateBid: cannot check existence of the user: %v", err)
 		return entity.Bid{}, ErrUserNotFound
 	}
 
 	if input.AuthorType == "Organization" {
-		_, err := s.repo.Auth.GetUserOrganizationId(ctx, input.AuthorId)
+		_, err := userMemberships(ctx, s.repo, input.AuthorId)
 		if err != nil {
 			if errors.Is(err, repoerrs.ErrNotFound) {
 				return entity.Bid{}, ErrNotEnoughPermissions
 			}
-			logrus.Errorf("BidService.CreateBid: cannot get user organization id: %v", err)
+			logger.FromContext(ctx).Errorf("BidService.CreateBid: cannot get user organization id: %v", err)
 			return entity.Bid{}, ErrNotEnoughPermissions
 		}
 	}
 
+	bidLots, err := s.bidLots(ctx, tender, input)
+	if err != nil {
+		return entity.Bid{}, err
+	}
+
 	bid := entity.Bid{
 		Name:        input.Name,
 		Description: input.Description,
@@ -59,53 +81,154 @@ func (s *BidService) CreateBid(ctx context.Context, input entity.CreateBidInput)
 		AuthorType:  input.AuthorType,
 		AuthorId:    input.AuthorId,
 		Version:     1,
+		Price:       input.Price,
+		Currency:    optionalString(input.Currency),
+	}
+
+	err = inTx(ctx, s.repo, "BidService.CreateBid", ErrCannotCreateBid, func(repos *repository.Repository) error {
+		var err error
+		bid, err = repos.Bid.CreateBid(ctx, bid)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("BidService.CreateBid: cannot create bid: %v", err)
+			return ErrCannotCreateBid
+		}
+
+		for i := range bidLots {
+			bidLots[i].BidId = bid.Id
+		}
+		if err := repos.Lot.CreateBidLots(ctx, bidLots); err != nil {
+			logger.FromContext(ctx).Errorf("BidService.CreateBid: cannot create bid lots: %v", err)
+			return ErrCannotCreateBid
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.Bid{}, err
 	}
+	s.metrics.BidCreated()
 
-	return s.repo.Bid.CreateBid(ctx, bid)
+	return bid, nil
 }
-func (s *BidService) GetBidsByUserId(ctx context.Context, limit int, offset int, userId string) ([]entity.Bid, error) {
-	return s.repo.Bid.GetBidsByUserId(ctx, limit, offset, userId)
+func (s *BidService) bidLots(ctx context.Context, tender entity.Tender, input entity.CreateBidInput) ([]entity.BidLot, error) {
+	lots, err := s.repo.Lot.GetLots(ctx, tender.Id)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("BidService.bidLots: cannot get lots: %v", err)
+		return nil, ErrCannotGetLot
+	}
+
+	if len(input.Lots) == 0 {
+		if len(lots) != 1 {
+			return nil, ErrLotRequired
+		}
+		if lots[0].Status != "Open" {
+			return nil, ErrLotClosed
+		}
+		if err := checkLotPrice(tender, lots[0], input.Price, &input.Currency); err != nil {
+			return nil, err
+		}
+		return []entity.BidLot{{LotId: lots[0].Id, Price: input.Price}}, nil
+	}
+
+	byId := make(map[string]entity.Lot, len(lots))
+	for _, lot := range lots {
+		byId[lot.Id] = lot
+	}
+
+	bidLots := make([]entity.BidLot, 0, len(input.Lots))
+	seen := make(map[string]struct{}, len(input.Lots))
+	for _, in := range input.Lots {
+		lot, ok := byId[in.LotId]
+		if !ok {
+			return nil, ErrLotNotFound
+		}
+		if _, ok := seen[lot.Id]; ok {
+			return nil, ErrDuplicateLot
+		}
+		seen[lot.Id] = struct{}{}
+		if lot.Status != "Open" {
+			return nil, ErrLotClosed
+		}
+		if err := checkLotPrice(tender, lot, in.Price, &input.Currency); err != nil {
+			return nil, err
+		}
+		bidLots = append(bidLots, entity.BidLot{LotId: lot.Id, Price: in.Price})
+	}
+
+	return bidLots, nil
 }
-func (s *BidService) GetBidsForTender(ctx context.Context, tenderId string, userId string, limit int, offset int) ([]entity.Bid, error) {
-	tender, err := s.repo.Tender.GetTenderById(ctx, tenderId)
+func (s *BidService) GetBidsByUserId(ctx context.Context, page entity.PageRequest, userId string) (entity.Page[entity.Bid], error) {
+	ctx, span := tracer.Start(ctx, "BidService.GetBidsByUserId")
+	defer span.End()
+
+	bids, err := s.repo.Bid.GetBidsByUserId(ctx, page, userId)
 	if err != nil {
-		if errors.Is(err, repoerrs.ErrNotFound) {
-			return nil, ErrTenderNotFound
+		if errors.Is(err, repoerrs.ErrInvalidCursor) {
+			return entity.Page[entity.Bid]{}, ErrInvalidCursor
 		}
-		logrus.Errorf("BidService.GetBidsForTender: cannot get tender: %v", err)
-		return nil, ErrCannotGetTender
+		logger.FromContext(ctx).Errorf("BidService.GetBidsByUserId: cannot get bids: %v", err)
+		return entity.Page[entity.Bid]{}, ErrCannotGetBid
 	}
 
-	userOrganizationId, err := s.repo.Auth.GetUserOrganizationId(ctx, userId)
+	return bids, nil
+}
+func (s *BidService) GetBidsForTender(ctx context.Context, tenderId string, userId string, page entity.PageRequest, filter entity.BidFilter) (entity.TenderBids, error) {
+	ctx, span := tracer.Start(ctx, "BidService.GetBidsForTender")
+	defer span.End()
+
+	tender, err := s.repo.Tender.GetTenderById(ctx, tenderId)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
-			return nil, ErrNotEnoughPermissions
+			return entity.TenderBids{}, ErrTenderNotFound
 		}
-		logrus.Errorf("BidService.GetBidsForTender: cannot get user organization id: %v", err)
-		return nil, ErrNotEnoughPermissions
+		logger.FromContext(ctx).Errorf("BidService.GetBidsForTender: cannot get tender: %v", err)
+		return entity.TenderBids{}, ErrCannotGetTender
 	}
-	if userOrganizationId != tender.OrganizationId {
-		return nil, ErrNotEnoughPermissions
+
+	if err := checkPermission(ctx, s.repo, userId, tender.OrganizationId, entity.PermissionTenderView); err != nil {
+		return entity.TenderBids{}, err
 	}
 
-	bids, err := s.repo.Bid.GetBidsForTender(ctx, tenderId, limit, offset)
+	if bidsSealed(tender) {
+		filter = entity.BidFilter{LotId: filter.LotId, SortBy: "created_at", SortOrder: "asc"}
+	}
+
+	bids, err := s.repo.Bid.GetBidsForTender(ctx, tenderId, page, filter)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
-			return nil, ErrBidNotFound
+			return entity.TenderBids{}, ErrBidNotFound
 		}
-		logrus.Errorf("BidService.GetBidsForTender: cannot get bid: %v", err)
-		return nil, ErrCannotGetBid
+		if errors.Is(err, repoerrs.ErrInvalidCursor) {
+			return entity.TenderBids{}, ErrInvalidCursor
+		}
+		logger.FromContext(ctx).Errorf("BidService.GetBidsForTender: cannot get bid: %v", err)
+		return entity.TenderBids{}, ErrCannotGetBid
 	}
 
-	return bids, nil
+	if !bidsSealed(tender) {
+		return entity.TenderBids{Count: len(bids.Items), Bids: bids.Items, NextCursor: bids.NextCursor, Total: bids.Total}, nil
+	}
+
+	count, err := s.repo.Bid.CountBidsForTender(ctx, tenderId, filter.LotId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("BidService.GetBidsForTender: cannot count bids: %v", err)
+		return entity.TenderBids{}, ErrCannotGetBid
+	}
+	for i := range bids.Items {
+		bids.Items[i] = sealBid(bids.Items[i])
+	}
+
+	return entity.TenderBids{Sealed: true, Count: count, Bids: bids.Items, NextCursor: bids.NextCursor, Total: count}, nil
 }
 func (s *BidService) GetBidStatus(ctx context.Context, bidId string, userId string) (string, error) {
+	ctx, span := tracer.Start(ctx, "BidService.GetBidStatus")
+	defer span.End()
+
 	bid, err := s.repo.Bid.GetBidById(ctx, bidId)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
 			return "", ErrBidNotFound
 		}
-		logrus.Errorf("BidService.GetBidStatus: cannot get bid: %v", err)
+		logger.FromContext(ctx).Errorf("BidService.GetBidStatus: cannot get bid: %v", err)
 		return "", ErrCannotGetBid
 	}
 
@@ -114,216 +237,595 @@ func (s *BidService) GetBidStatus(ctx context.Context, bidId string, userId stri
 			return "", ErrNotEnoughPermissions
 		}
 	} else {
-		userOrganizationId, err := s.repo.Auth.GetUserOrganizationId(ctx, userId)
+		if err := checkSharedOrganization(ctx, s.repo, userId, bid.AuthorId); err != nil {
+			return "", err
+		}
+	}
+
+	return bid.Status, nil
+}
+
+func (s *BidService) UpdateBidStatus(ctx context.Context, bidId, status, userId string) (entity.Bid, error) {
+	ctx, span := tracer.Start(ctx, "BidService.UpdateBidStatus")
+	defer span.End()
+
+	var result entity.Bid
+	err := inTx(ctx, s.repo, "BidService.UpdateBidStatus", ErrCannotUpdateBid, func(repos *repository.Repository) error {
+		bid, err := repos.Bid.GetBidById(ctx, bidId)
 		if err != nil {
 			if errors.Is(err, repoerrs.ErrNotFound) {
-				return "", ErrNotEnoughPermissions
+				return ErrBidNotFound
 			}
-			logrus.Errorf("BidService.GetBidStatus: cannot get user organization id: %v", err)
-			return "", ErrNotEnoughPermissions
+			logger.FromContext(ctx).Errorf("BidService.UpdateBidStatus: cannot get bid: %v", err)
+			return ErrCannotGetBid
 		}
-		tenderOrganizationId, _ := s.repo.Auth.GetUserOrganizationId(ctx, bid.AuthorId)
-		if userOrganizationId != tenderOrganizationId {
-			return "", ErrNotEnoughPermissions
+
+		if bid.Status == "Canceled" {
+			return bidCanceledError(bid.Id)
+		} else if bid.Status == status {
+			return statusUnchangedError(status)
+		} else if bid.Status == "Published" && status == "Created" {
+			return statusRevertedError(bid.Status, status)
 		}
+
+		if bid.AuthorType == "User" {
+			if bid.AuthorId != userId {
+				return ErrNotEnoughPermissions
+			}
+		} else {
+			if err := checkSharedOrganization(ctx, repos, userId, bid.AuthorId); err != nil {
+				return err
+			}
+		}
+		if status == "Published" {
+			if err := checkSubmissionOpen(ctx, repos, bid.TenderId); err != nil {
+				return err
+			}
+		}
+
+		bid, err = repos.Bid.UpdateBidStatus(ctx, bidId, status)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrBidNotFound
+			}
+			logger.FromContext(ctx).Errorf("BidService.UpdateBidStatus: cannot update bid: %v", err)
+			return ErrCannotUpdateBid
+		}
+
+		result = bid
+		return nil
+	})
+	if err != nil {
+		return entity.Bid{}, err
 	}
 
-	return bid.Status, nil
+	return result, nil
 }
+func (s *BidService) EditBid(ctx context.Context, bidId string, userId string, input entity.EditBidInput) (entity.Bid, error) {
+	ctx, span := tracer.Start(ctx, "BidService.EditBid")
+	defer span.End()
 
-func (s *BidService) UpdateBidStatus(ctx context.Context, bidId, status, userId string) (entity.Bid, error) {
-	bid, err := s.repo.Bid.GetBidById(ctx, bidId)
-	if err != nil {
-		if errors.Is(err, repoerrs.ErrNotFound) {
-			return entity.Bid{}, ErrBidNotFound
+	var result entity.Bid
+	err := inTx(ctx, s.repo, "BidService.EditBid", ErrCannotUpdateBid, func(repos *repository.Repository) error {
+		bid, err := repos.Bid.GetBidById(ctx, bidId)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrBidNotFound
+			}
+			logger.FromContext(ctx).Errorf("BidService.EditBid: cannot get bid: %v", err)
+			return ErrCannotGetBid
 		}
-		logrus.Errorf("BidService.UpdateBidStatus: cannot get bid: %v", err)
-		return entity.Bid{}, ErrCannotGetBid
-	}
 
-	if bid.Status == "Canceled" {
-		return entity.Bid{}, fmt.Errorf("cannot edit canceled bid")
-	} else if bid.Status == status {
-		return entity.Bid{}, fmt.Errorf("impossible change the status to the same")
-	} else if bid.Status == "Published" && status == "Created" {
-		return entity.Bid{}, fmt.Errorf("impossible change the status to the previous one")
-	}
+		if bid.Status == "Canceled" {
+			return bidCanceledError(bid.Id)
+		}
 
-	if bid.AuthorType == "User" {
-		if bid.AuthorId != userId {
-			return entity.Bid{}, ErrNotEnoughPermissions
+		if bid.AuthorType == "User" {
+			if bid.AuthorId != userId {
+				return ErrNotEnoughPermissions
+			}
+		} else {
+			if err := checkSharedOrganization(ctx, repos, userId, bid.AuthorId); err != nil {
+				return err
+			}
 		}
-	} else {
-		userOrganizationId, err := s.repo.Auth.GetUserOrganizationId(ctx, userId)
+
+		tender, err := lockTender(ctx, repos, bid.TenderId)
 		if err != nil {
-			if errors.Is(err, repoerrs.ErrNotFound) {
-				return entity.Bid{}, ErrNotEnoughPermissions
+			return err
+		}
+		if deadlinePassed(tender) {
+			return ErrSubmissionDeadlinePassed
+		}
+
+		price, currency := bid.Price, bid.Currency
+		if input.Price != nil {
+			price = input.Price
+		}
+		if input.Currency != nil {
+			currency = input.Currency
+		}
+		if input.Price != nil || input.Currency != nil {
+			if err := checkBidPrice(tender, price, currency); err != nil {
+				return err
 			}
-			logrus.Errorf("BidService.UpdateBidStatus: cannot get user organization id: %v", err)
-			return entity.Bid{}, ErrNotEnoughPermissions
+		}
+		if input.Version != nil && *input.Version != bid.Version {
+			return &VersionConflictError{CurrentVersion: bid.Version}
 		}
 
-		tenderOrganizationId, _ := s.repo.Auth.GetUserOrganizationId(ctx, bid.AuthorId)
+		lots, err := repos.Lot.GetLots(ctx, tender.Id)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("BidService.EditBid: cannot get lots: %v", err)
+			return ErrCannotGetLot
+		}
+		bidLots, err := repos.Lot.GetBidLots(ctx, bidId)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("BidService.EditBid: cannot get bid lots: %v", err)
+			return ErrCannotGetLot
+		}
+		bidLots, err = editBidLots(tender, lots, bidLots, input, price, currency)
+		if err != nil {
+			return err
+		}
 
-		if userOrganizationId != tenderOrganizationId {
-			return entity.Bid{}, ErrNotEnoughPermissions
+		bid, err = repos.Bid.UpdateBid(ctx, bidId, input)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrBidNotFound
+			}
+			if errors.Is(err, repoerrs.ErrConflict) {
+				return s.versionConflict(ctx, repos, bidId)
+			}
+			logger.FromContext(ctx).Errorf("BidService.EditBid: cannot update bid: %v", err)
+			return ErrCannotUpdateBid
 		}
-	}
 
-	bid, err = s.repo.Bid.UpdateBidStatus(ctx, bidId, status)
-	if err != nil {
-		if errors.Is(err, repoerrs.ErrNotFound) {
-			return entity.Bid{}, ErrBidNotFound
+		for _, bidLot := range bidLots {
+			if err := repos.Lot.UpdateBidLotPrice(ctx, bidLot); err != nil {
+				logger.FromContext(ctx).Errorf("BidService.EditBid: cannot update bid lot price: %v", err)
+				return ErrCannotUpdateBid
+			}
 		}
-		logrus.Errorf("BidService.UpdateBidStatus: cannot update bid: %v", err)
-		return entity.Bid{}, ErrCannotUpdateBid
+
+		result = bid
+		return nil
+	})
+	if err != nil {
+		return entity.Bid{}, err
 	}
 
-	return bid, nil
+	return result, nil
 }
-func (s *BidService) EditBid(ctx context.Context, bidId string, userId string, input entity.EditBidInput) (entity.Bid, error) {
-	bid, err := s.repo.Bid.GetBidById(ctx, bidId)
-	if err != nil {
-		if errors.Is(err, repoerrs.ErrNotFound) {
-			return entity.Bid{}, ErrBidNotFound
+func (s *BidService) SubmitBidDecision(ctx context.Context, bidId, lotId, decision, userId string) (entity.BidWithDecisions, error) {
+	ctx, span := tracer.Start(ctx, "BidService.SubmitBidDecision")
+	defer span.End()
+
+	var result entity.BidWithDecisions
+	var final string
+	var tenderClosed bool
+	err := inTx(ctx, s.repo, "BidService.SubmitBidDecision", ErrCannotUpdateBid, func(repos *repository.Repository) error {
+		bid, err := repos.Bid.GetBidById(ctx, bidId)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrBidNotFound
+			}
+			logger.FromContext(ctx).Errorf("BidService.SubmitBidDecision: cannot get bid: %v", err)
+			return ErrCannotGetBid
+		}
+		if bid.Status == "Canceled" {
+			return bidCanceledError(bid.Id)
+		}
+		if bid.Status == "Created" {
+			return ErrNotEnoughPermissions
 		}
-		logrus.Errorf("BidService.EditBid: cannot get bid: %v", err)
-		return entity.Bid{}, ErrCannotGetBid
-	}
 
-	if bid.Status == "Canceled" {
-		return entity.Bid{}, fmt.Errorf("cannot edit canceled bid")
-	}
+		if err := repos.Tender.LockTender(ctx, bid.TenderId); err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrTenderNotFound
+			}
+			logger.FromContext(ctx).Errorf("BidService.SubmitBidDecision: cannot lock tender: %v", err)
+			return ErrCannotUpdateTender
+		}
+		tender, err := repos.Tender.GetTenderById(ctx, bid.TenderId)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrTenderNotFound
+			}
+			logger.FromContext(ctx).Errorf("BidService.SubmitBidDecision: cannot get tender: %v", err)
+			return ErrCannotGetTender
+		}
+		if err := checkPermission(ctx, repos, userId, tender.OrganizationId, entity.PermissionBidDecision); err != nil {
+			return err
+		}
+		if tender.Status == "Closed" {
+			return tenderClosedError(tender.Id)
+		}
+		if bidsSealed(tender) {
+			return ErrBidsSealed
+		}
 
-	if bid.AuthorType == "User" {
-		if bid.AuthorId != userId {
-			return entity.Bid{}, ErrNotEnoughPermissions
+		bidLots, err := repos.Lot.GetBidLots(ctx, bidId)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("BidService.SubmitBidDecision: cannot get bid lots: %v", err)
+			return ErrCannotGetLot
 		}
-	} else {
-		userOrganizationId, err := s.repo.Auth.GetUserOrganizationId(ctx, userId)
+		bidLot, err := selectBidLot(bidLots, lotId)
+		if err != nil {
+			return err
+		}
+		if bidLot.Decision != nil {
+			return ErrBidAlreadyDecided
+		}
+		lot, err := repos.Lot.GetLotById(ctx, bidLot.LotId)
 		if err != nil {
 			if errors.Is(err, repoerrs.ErrNotFound) {
-				return entity.Bid{}, ErrNotEnoughPermissions
+				return ErrLotNotFound
 			}
-			logrus.Errorf("BidService.EditBid: cannot get user organization id: %v", err)
-			return entity.Bid{}, ErrNotEnoughPermissions
+			logger.FromContext(ctx).Errorf("BidService.SubmitBidDecision: cannot get lot: %v", err)
+			return ErrCannotGetLot
+		}
+		if lot.Status != "Open" {
+			return ErrLotClosed
+		}
+
+		decisions, quorum, err := s.getDecisionsAndQuorum(ctx, repos, bidId, tender.OrganizationId)
+		if err != nil {
+			return err
 		}
 
-		tenderOrganizationId, _ := s.repo.Auth.GetUserOrganizationId(ctx, bid.AuthorId)
+		bidDecision, err := repos.Bid.CreateBidDecision(ctx, entity.BidDecision{
+			BidId:    bidId,
+			LotId:    lot.Id,
+			UserId:   userId,
+			Decision: decision,
+		})
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrAlreadyExists) {
+				return ErrDecisionAlreadySubmitted
+			}
+			logger.FromContext(ctx).Errorf("BidService.SubmitBidDecision: cannot create bid decision: %v", err)
+			return ErrCannotUpdateBid
+		}
+		decisions = append(decisions, bidDecision)
 
-		if userOrganizationId != tenderOrganizationId {
-			return entity.Bid{}, ErrNotEnoughPermissions
+		final = finalDecision(decisionsForLot(decisions, lot.Id), quorum)
+		if final != "" {
+			if err := repos.Lot.SubmitBidLotDecision(ctx, bidId, lot.Id, final); err != nil {
+				logger.FromContext(ctx).Errorf("BidService.SubmitBidDecision: cannot update bid lot: %v", err)
+				return ErrCannotUpdateBid
+			}
+			for i := range bidLots {
+				if bidLots[i].LotId == lot.Id {
+					bidLots[i].Decision = &final
+				}
+			}
+		}
+
+		if final == "Approved" {
+			_, err := repos.Lot.CloseLot(ctx, lot.Id, "Awarded", &bidId)
+			if err != nil {
+				if errors.Is(err, repoerrs.ErrNotFound) {
+					return ErrLotClosed
+				}
+				logger.FromContext(ctx).Errorf("BidService.SubmitBidDecision: cannot award lot: %v", err)
+				return ErrCannotUpdateLot
+			}
+			tenderClosed, err = closeTenderIfResolved(ctx, repos, tender)
+			if err != nil {
+				return err
+			}
+		}
+
+		overall := overallDecision(bidLots)
+		if overall != "" {
+			bid, err = repos.Bid.SubmitBidDecision(ctx, bidId, overall)
+			if err != nil {
+				if errors.Is(err, repoerrs.ErrNotFound) {
+					return ErrBidNotFound
+				}
+				logger.FromContext(ctx).Errorf("BidService.SubmitBidDecision: cannot update bid: %v", err)
+				return ErrCannotUpdateBid
+			}
 		}
+
+		result = entity.BidWithDecisions{
+			Bid:       bid,
+			Decision:  overall,
+			Decisions: decisions,
+			Lots:      bidLots,
+			Quorum:    quorum,
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.BidWithDecisions{}, err
+	}
+
+	s.metrics.BidDecisionSubmitted(decision)
+	if final != "" {
+		s.metrics.BidDecided(final)
+	}
+	if tenderClosed {
+		s.metrics.TendersClosed(1)
 	}
 
-	bid, err = s.repo.Bid.UpdateBid(ctx, bidId, input)
+	return result, nil
+}
+func (s *BidService) GetBidDecisions(ctx context.Context, bidId string, userId string) (entity.BidWithDecisions, error) {
+	ctx, span := tracer.Start(ctx, "BidService.GetBidDecisions")
+	defer span.End()
+
+	bid, err := s.repo.Bid.GetBidById(ctx, bidId)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
-			return entity.Bid{}, ErrBidNotFound
+			return entity.BidWithDecisions{}, ErrBidNotFound
 		}
-		logrus.Errorf("BidService.EditBid: cannot update bid: %v", err)
-		return entity.Bid{}, ErrCannotUpdateBid
+		logger.FromContext(ctx).Errorf("BidService.GetBidDecisions: cannot get bid: %v", err)
+		return entity.BidWithDecisions{}, ErrCannotGetBid
 	}
 
-	return bid, nil
-}
-func (s *BidService) SubmitBidDecision(ctx context.Context, bidId, decision, userId string) (entity.Bid, error) {
-	bid, err := s.repo.Bid.GetBidById(ctx, bidId)
+	tender, err := s.repo.Tender.GetTenderById(ctx, bid.TenderId)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
-			return entity.Bid{}, ErrBidNotFound
+			return entity.BidWithDecisions{}, ErrTenderNotFound
+		}
+		logger.FromContext(ctx).Errorf("BidService.GetBidDecisions: cannot get tender: %v", err)
+		return entity.BidWithDecisions{}, ErrCannotGetTender
+	}
+	if err := checkPermission(ctx, s.repo, userId, tender.OrganizationId, entity.PermissionTenderView); err != nil {
+		return entity.BidWithDecisions{}, err
+	}
+
+	decisions, quorum, err := s.getDecisionsAndQuorum(ctx, s.repo, bidId, tender.OrganizationId)
+	if err != nil {
+		return entity.BidWithDecisions{}, err
+	}
+	bidLots, err := s.repo.Lot.GetBidLots(ctx, bidId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("BidService.GetBidDecisions: cannot get bid lots: %v", err)
+		return entity.BidWithDecisions{}, ErrCannotGetLot
+	}
+	if bidsSealed(tender) {
+		bid = sealBid(bid)
+		for i := range bidLots {
+			bidLots[i].Price = nil
 		}
-		logrus.Errorf("BidService.SubmitBidDecision: cannot get bid: %v", err)
-		return entity.Bid{}, ErrCannotGetBid
 	}
-	if bid.Status == "Canceled" {
-		return entity.Bid{}, fmt.Errorf("cannot edit canceled bid")
+
+	return entity.BidWithDecisions{
+		Bid:       bid,
+		Decision:  overallDecision(bidLots),
+		Decisions: decisions,
+		Lots:      bidLots,
+		Quorum:    quorum,
+	}, nil
+}
+
+func (s *BidService) getDecisionsAndQuorum(ctx context.Context, repos *repository.Repository, bidId string, organizationId string) ([]entity.BidDecision, int, error) {
+	decisions, err := repos.Bid.GetBidDecisions(ctx, bidId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("BidService.getDecisionsAndQuorum: cannot get bid decisions: %v", err)
+		return nil, 0, ErrCannotGetBid
 	}
-	if bid.Status == "Created" {
-		return entity.Bid{}, ErrNotEnoughPermissions
+
+	members, err := repos.Auth.GetOrganizationMembers(ctx, organizationId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("BidService.getDecisionsAndQuorum: cannot get organization members: %v", err)
+		return nil, 0, ErrCannotGetMembers
+	}
+
+	return decisions, decisionQuorum(s.approvalQuorum, members), nil
+}
+
+// decisionQuorum caps the configured quorum by the number of responsibles allowed to decide, but never lets it
+// drop below a single approval.
+func decisionQuorum(approvalQuorum int, members []entity.OrganizationMember) int {
+	approvers := 0
+	for _, member := range members {
+		if slices.Contains(rolePermissions[member.Role], entity.PermissionBidDecision) {
+			approvers++
+		}
 	}
 
-	userOrganizationId, err := s.repo.Auth.GetUserOrganizationId(ctx, userId)
+	return max(1, min(approvalQuorum, approvers))
+}
+
+func checkSubmissionOpen(ctx context.Context, repo *repository.Repository, tenderId string) error {
+	tender, err := repo.Tender.GetTenderById(ctx, tenderId)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
-			return entity.Bid{}, ErrNotEnoughPermissions
+			return ErrTenderNotFound
 		}
-		logrus.Errorf("BidService.SubmitBidDecision: cannot get user organization id: %v", err)
-		return entity.Bid{}, ErrNotEnoughPermissions
+		logger.FromContext(ctx).Errorf("BidService.checkSubmissionOpen: cannot get tender: %v", err)
+		return ErrCannotGetTender
 	}
-	tender, _ := s.repo.Tender.GetTenderById(ctx, bid.TenderId)
-	if userOrganizationId != tender.OrganizationId {
-		return entity.Bid{}, ErrNotEnoughPermissions
+	if deadlinePassed(tender) {
+		return ErrSubmissionDeadlinePassed
 	}
 
-	if decision == "Approved" && tender.Status != "Closed" {
-		_, err := s.repo.Tender.UpdateTenderStatus(ctx, tender.Id, "Closed")
+	return nil
+}
+
+func sealBid(bid entity.Bid) entity.Bid {
+	bid.Name = ""
+	bid.Description = ""
+	bid.AuthorId = ""
+	bid.Price = nil
+	bid.Currency = nil
+	return bid
+}
+
+func finalDecision(decisions []entity.BidDecision, quorum int) string {
+	approvals := 0
+	for _, decision := range decisions {
+		if decision.Decision == "Rejected" {
+			return "Rejected"
+		}
+		approvals++
+	}
+	if approvals >= quorum {
+		return "Approved"
+	}
+	return ""
+}
+
+func (s *BidService) RollbackBid(ctx context.Context, bidId string, version int, userId string) (entity.Bid, error) {
+	ctx, span := tracer.Start(ctx, "BidService.RollbackBid")
+	defer span.End()
+
+	var result entity.Bid
+	err := inTx(ctx, s.repo, "BidService.RollbackBid", ErrCannotUpdateBid, func(repos *repository.Repository) error {
+		bid, err := repos.Bid.GetBidById(ctx, bidId)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrBidNotFound
+			}
+			logger.FromContext(ctx).Errorf("BidService.RollbackBid: cannot get bid: %v", err)
+			return ErrCannotGetBid
+		}
+		if bid.Version <= version {
+			return invalidRollbackError(bid.Version, version)
+		}
+		if bid.Status == "Canceled" {
+			return bidCanceledError(bid.Id)
+		}
+
+		if bid.AuthorType == "User" {
+			if bid.AuthorId != userId {
+				return ErrNotEnoughPermissions
+			}
+		} else {
+			if err := checkSharedOrganization(ctx, repos, userId, bid.AuthorId); err != nil {
+				return err
+			}
+		}
+
+		bid, err = repos.Bid.RollbackBid(ctx, bidId, version)
 		if err != nil {
 			if errors.Is(err, repoerrs.ErrNotFound) {
-				return entity.Bid{}, ErrTenderNotFound
+				return ErrBidNotFound
 			}
-			logrus.Errorf("TenderService.GetTenderStatus: cannot update tender: %v", err)
-			return entity.Bid{}, ErrCannotUpdateTender
+			logger.FromContext(ctx).Errorf("BidService.RollbackBid: cannot update bid: %v", err)
+			return ErrCannotUpdateBid
+		}
+
+		owner := entity.AttachmentOwner{Type: entity.AttachmentOwnerBid, Id: bidId}
+		if err := repos.Attachment.RestoreAttachments(ctx, owner, version, bid.Version); err != nil {
+			logger.FromContext(ctx).Errorf("BidService.RollbackBid: cannot restore attachments: %v", err)
+			return ErrCannotUpdateBid
 		}
+
+		result = bid
+		return nil
+	})
+	if err != nil {
+		return entity.Bid{}, err
 	}
 
-	bid, err = s.repo.Bid.SubmitBidDecision(ctx, bidId, decision)
+	return result, nil
+}
+
+func (s *BidService) GetBidVersions(ctx context.Context, bidId string, userId string) ([]entity.Bid, error) {
+	ctx, span := tracer.Start(ctx, "BidService.GetBidVersions")
+	defer span.End()
+
+	bid, err := s.getViewableBid(ctx, bidId, userId)
 	if err != nil {
-		if errors.Is(err, repoerrs.ErrNotFound) {
-			return entity.Bid{}, ErrBidNotFound
-		}
-		logrus.Errorf("BidService.SubmitBidDecision: cannot update bid: %v", err)
-		return entity.Bid{}, ErrCannotUpdateBid
+		return nil, err
 	}
 
-	return bid, nil
+	versions, err := s.repo.Bid.GetBidVersions(ctx, bidId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("BidService.GetBidVersions: cannot get bid versions: %v", err)
+		return nil, ErrCannotGetBid
+	}
+
+	return append(versions, bid), nil
 }
+func (s *BidService) GetBidVersion(ctx context.Context, bidId string, version int, userId string) (entity.Bid, error) {
+	ctx, span := tracer.Start(ctx, "BidService.GetBidVersion")
+	defer span.End()
 
-func (s *BidService) RollbackBid(ctx context.Context, bidId string, version int, userId string) (entity.Bid, error) {
+	bid, err := s.getViewableBid(ctx, bidId, userId)
+	if err != nil {
+		return entity.Bid{}, err
+	}
+
+	return s.getBidVersion(ctx, bid, version)
+}
+func (s *BidService) DiffBidVersions(ctx context.Context, bidId string, from int, to int, userId string) (entity.VersionDiff, error) {
+	ctx, span := tracer.Start(ctx, "BidService.DiffBidVersions")
+	defer span.End()
+
+	bid, err := s.getViewableBid(ctx, bidId, userId)
+	if err != nil {
+		return entity.VersionDiff{}, err
+	}
+
+	fromBid, err := s.getBidVersion(ctx, bid, from)
+	if err != nil {
+		return entity.VersionDiff{}, err
+	}
+	toBid, err := s.getBidVersion(ctx, bid, to)
+	if err != nil {
+		return entity.VersionDiff{}, err
+	}
+
+	return diffBids(fromBid, toBid), nil
+}
+
+func (s *BidService) getViewableBid(ctx context.Context, bidId string, userId string) (entity.Bid, error) {
 	bid, err := s.repo.Bid.GetBidById(ctx, bidId)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
 			return entity.Bid{}, ErrBidNotFound
 		}
-		logrus.Errorf("BidService.EditBid: cannot get bid: %v", err)
+		logger.FromContext(ctx).Errorf("BidService.getViewableBid: cannot get bid: %v", err)
 		return entity.Bid{}, ErrCannotGetBid
 	}
-	if bid.Version <= version {
-		return entity.Bid{}, fmt.Errorf("cannot roll back to the current and higher version")
-	}
-	if bid.Status == "Canceled" {
-		return entity.Bid{}, fmt.Errorf("cannot edit canceled bid")
-	}
 
 	if bid.AuthorType == "User" {
 		if bid.AuthorId != userId {
 			return entity.Bid{}, ErrNotEnoughPermissions
 		}
 	} else {
-		userOrganizationId, err := s.repo.Auth.GetUserOrganizationId(ctx, userId)
-		if err != nil {
-			if errors.Is(err, repoerrs.ErrNotFound) {
-				return entity.Bid{}, ErrNotEnoughPermissions
-			}
-			logrus.Errorf("BidService.RollbackBid: cannot get user organization id: %v", err)
-			return entity.Bid{}, ErrNotEnoughPermissions
+		if err := checkSharedOrganization(ctx, s.repo, userId, bid.AuthorId); err != nil {
+			return entity.Bid{}, err
 		}
+	}
 
-		tenderOrganizationId, _ := s.repo.Auth.GetUserOrganizationId(ctx, bid.AuthorId)
+	return bid, nil
+}
 
-		if userOrganizationId != tenderOrganizationId {
-			return entity.Bid{}, ErrNotEnoughPermissions
-		}
+func (s *BidService) getBidVersion(ctx context.Context, current entity.Bid, version int) (entity.Bid, error) {
+	if version == current.Version {
+		return current, nil
+	}
+	if version > current.Version {
+		return entity.Bid{}, ErrVersionNotFound
 	}
 
-	bid, err = s.repo.Bid.RollbackBid(ctx, bidId, version)
+	bid, err := s.repo.Bid.GetBidVersion(ctx, current.Id, version)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
-			return entity.Bid{}, ErrBidNotFound
+			return entity.Bid{}, ErrVersionNotFound
 		}
-		logrus.Errorf("BidService.RollbackBid: cannot update bid: %v", err)
-		return entity.Bid{}, ErrCannotUpdateBid
+		logger.FromContext(ctx).Errorf("BidService.getBidVersion: cannot get bid version: %v", err)
+		return entity.Bid{}, ErrCannotGetBid
 	}
 
 	return bid, nil
 }
+
+func (s *BidService) versionConflict(ctx context.Context, repos *repository.Repository, bidId string) error {
+	bid, err := repos.Bid.GetBidById(ctx, bidId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrBidNotFound
+		}
+		logger.FromContext(ctx).Errorf("BidService.versionConflict: cannot get bid: %v", err)
+		return ErrCannotGetBid
+	}
+	return &VersionConflictError{CurrentVersion: bid.Version}
+}
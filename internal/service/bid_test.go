@@ -0,0 +1,80 @@
+package service
+
+import (
+	"tender-service/internal/entity"
+	"testing"
+)
+
+func TestFinalDecision(t *testing.T) {
+	approved := entity.BidDecision{Decision: "Approved"}
+	rejected := entity.BidDecision{Decision: "Rejected"}
+
+	tests := []struct {
+		name      string
+		decisions []entity.BidDecision
+		quorum    int
+		want      string
+	}{
+		{name: "no decisions", decisions: nil, quorum: 1, want: ""},
+		{name: "quorum not reached", decisions: []entity.BidDecision{approved, approved}, quorum: 3, want: ""},
+		{name: "quorum reached", decisions: []entity.BidDecision{approved, approved, approved}, quorum: 3, want: "Approved"},
+		{name: "quorum exceeded", decisions: []entity.BidDecision{approved, approved}, quorum: 1, want: "Approved"},
+		{name: "single rejection", decisions: []entity.BidDecision{rejected}, quorum: 3, want: "Rejected"},
+		{name: "rejection vetoes reached quorum", decisions: []entity.BidDecision{approved, approved, rejected}, quorum: 2, want: "Rejected"},
+		{name: "rejection before approvals", decisions: []entity.BidDecision{rejected, approved, approved}, quorum: 2, want: "Rejected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := finalDecision(tt.decisions, tt.quorum); got != tt.want {
+				t.Errorf("finalDecision() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecisionQuorum(t *testing.T) {
+	member := func(role string) entity.OrganizationMember {
+		return entity.OrganizationMember{Role: role}
+	}
+
+	tests := []struct {
+		name           string
+		approvalQuorum int
+		members        []entity.OrganizationMember
+		want           int
+	}{
+		{
+			name:           "configured quorum",
+			approvalQuorum: 2,
+			members:        []entity.OrganizationMember{member(entity.RoleOwner), member(entity.RoleApprover), member(entity.RoleApprover)},
+			want:           2,
+		},
+		{
+			name:           "capped by deciders",
+			approvalQuorum: 3,
+			members:        []entity.OrganizationMember{member(entity.RoleOwner), member(entity.RoleApprover)},
+			want:           2,
+		},
+		{
+			name:           "roles without decision permission are not counted",
+			approvalQuorum: 3,
+			members:        []entity.OrganizationMember{member(entity.RoleOwner), member(entity.RoleEditor), member(entity.RoleViewer)},
+			want:           1,
+		},
+		{
+			name:           "at least one approval",
+			approvalQuorum: 3,
+			members:        nil,
+			want:           1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decisionQuorum(tt.approvalQuorum, tt.members); got != tt.want {
+				t.Errorf("decisionQuorum() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package service
+
+import "context"
+
+type organizationIdCtxKey struct{}
+
+func WithOrganizationId(ctx context.Context, organizationId string) context.Context {
+	return context.WithValue(ctx, organizationIdCtxKey{}, organizationId)
+}
+
+func OrganizationIdFromContext(ctx context.Context) (string, bool) {
+	organizationId, ok := ctx.Value(organizationIdCtxKey{}).(string)
+	return organizationId, ok && organizationId != ""
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"golang.org/x/crypto/bcrypt"
+	"slices"
+	"strings"
+	"tender-service/internal/entity"
+	"tender-service/internal/logger"
+	"tender-service/internal/repository"
+	"tender-service/internal/repository/repoerrs"
+	"time"
+)
+
+type EmployeeService struct {
+	repo          *repository.Repository
+	resetTokenTTL time.Duration
+	selfSignUp    bool
+}
+
+func NewEmployeeService(repo *repository.Repository, resetTokenTTL time.Duration, selfSignUp bool) *EmployeeService {
+	return &EmployeeService{repo: repo, resetTokenTTL: resetTokenTTL, selfSignUp: selfSignUp}
+}
+
+func (s *EmployeeService) CreateEmployee(ctx context.Context, input entity.CreateEmployeeInput, userId *string) (entity.Employee, error) {
+	ctx, span := tracer.Start(ctx, "EmployeeService.CreateEmployee")
+	defer span.End()
+
+	if err := s.checkCanCreateEmployee(ctx, userId); err != nil {
+		return entity.Employee{}, err
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("EmployeeService.CreateEmployee: cannot hash password: %v", err)
+		return entity.Employee{}, ErrCannotCreateUser
+	}
+
+	employee := entity.Employee{
+		Username:  input.Username,
+		FirstName: input.FirstName,
+		LastName:  input.LastName,
+	}
+
+	employee, err = s.repo.Auth.CreateEmployee(ctx, employee, string(passwordHash))
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrAlreadyExists) {
+			return entity.Employee{}, ErrUserAlreadyExists
+		}
+		logger.FromContext(ctx).Errorf("EmployeeService.CreateEmployee: cannot create employee: %v", err)
+		return entity.Employee{}, ErrCannotCreateUser
+	}
+
+	return employee, nil
+}
+func (s *EmployeeService) GetEmployees(ctx context.Context, limit int, offset int) ([]entity.Employee, error) {
+	ctx, span := tracer.Start(ctx, "EmployeeService.GetEmployees")
+	defer span.End()
+
+	employees, err := s.repo.Auth.GetEmployees(ctx, limit, offset)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("EmployeeService.GetEmployees: cannot get employees: %v", err)
+		return nil, ErrCannotGetUser
+	}
+
+	return employees, nil
+}
+func (s *EmployeeService) GetEmployeeById(ctx context.Context, id string) (entity.Employee, error) {
+	ctx, span := tracer.Start(ctx, "EmployeeService.GetEmployeeById")
+	defer span.End()
+
+	employee, err := s.repo.Auth.GetEmployeeById(ctx, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.Employee{}, ErrUserNotFound
+		}
+		logger.FromContext(ctx).Errorf("EmployeeService.GetEmployeeById: cannot get employee: %v", err)
+		return entity.Employee{}, ErrCannotGetUser
+	}
+
+	return employee, nil
+}
+func (s *EmployeeService) EditEmployee(ctx context.Context, id string, userId string, input entity.EditEmployeeInput) (entity.Employee, error) {
+	ctx, span := tracer.Start(ctx, "EmployeeService.EditEmployee")
+	defer span.End()
+
+	if id != userId {
+		return entity.Employee{}, ErrNotEnoughPermissions
+	}
+
+	var passwordHash *string
+	if input.Password != nil {
+		hash, err := bcrypt.GenerateFromPassword([]byte(*input.Password), bcrypt.DefaultCost)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("EmployeeService.EditEmployee: cannot hash password: %v", err)
+			return entity.Employee{}, ErrCannotUpdateUser
+		}
+		hashString := string(hash)
+		passwordHash = &hashString
+	}
+
+	employee, err := s.repo.Auth.UpdateEmployee(ctx, id, input, passwordHash)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.Employee{}, ErrUserNotFound
+		}
+		logger.FromContext(ctx).Errorf("EmployeeService.EditEmployee: cannot update employee: %v", err)
+		return entity.Employee{}, ErrCannotUpdateUser
+	}
+
+	return employee, nil
+}
+func (s *EmployeeService) DeleteEmployee(ctx context.Context, id string, userId string) error {
+	ctx, span := tracer.Start(ctx, "EmployeeService.DeleteEmployee")
+	defer span.End()
+
+	if id != userId {
+		return ErrNotEnoughPermissions
+	}
+
+	members, err := s.repo.Auth.GetUserMemberships(ctx, id)
+	if err != nil && !errors.Is(err, repoerrs.ErrNotFound) {
+		logger.FromContext(ctx).Errorf("EmployeeService.DeleteEmployee: cannot get user memberships: %v", err)
+		return ErrCannotDeleteUser
+	}
+	slices.SortFunc(members, func(a, b entity.OrganizationMember) int {
+		return strings.Compare(a.OrganizationId, b.OrganizationId)
+	})
+
+	return inTx(ctx, s.repo, "EmployeeService.DeleteEmployee", ErrCannotDeleteUser, func(repos *repository.Repository) error {
+		for _, member := range members {
+			if err := checkNotLastOwner(ctx, repos, member.OrganizationId, id); err != nil {
+				return err
+			}
+		}
+
+		err := repos.Auth.DeleteEmployee(ctx, id)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrUserNotFound
+			}
+			logger.FromContext(ctx).Errorf("EmployeeService.DeleteEmployee: cannot delete employee: %v", err)
+			return ErrCannotDeleteUser
+		}
+
+		return nil
+	})
+}
+
+// IssuePasswordReset lets a responsible who manages roles in every organization of the employee hand them a
+// one-time token for setting a new password.
+func (s *EmployeeService) IssuePasswordReset(ctx context.Context, id string, userId string) (entity.PasswordReset, error) {
+	ctx, span := tracer.Start(ctx, "EmployeeService.IssuePasswordReset")
+	defer span.End()
+
+	members, err := s.repo.Auth.GetUserMemberships(ctx, id)
+	if err != nil && !errors.Is(err, repoerrs.ErrNotFound) {
+		logger.FromContext(ctx).Errorf("EmployeeService.IssuePasswordReset: cannot get user memberships: %v", err)
+		return entity.PasswordReset{}, ErrCannotIssueResetToken
+	}
+	if len(members) == 0 {
+		return entity.PasswordReset{}, ErrNotEnoughPermissions
+	}
+	for _, member := range members {
+		if err := checkPermission(ctx, s.repo, userId, member.OrganizationId, entity.PermissionRoleManage); err != nil {
+			return entity.PasswordReset{}, err
+		}
+	}
+
+	return s.issuePasswordReset(ctx, id, &userId)
+}
+
+// IssuePasswordResetByUsername issues a reset token without any permission check. It is meant for operators
+// bootstrapping accounts from the command line and is not exposed over HTTP.
+func (s *EmployeeService) IssuePasswordResetByUsername(ctx context.Context, username string) (entity.PasswordReset, error) {
+	ctx, span := tracer.Start(ctx, "EmployeeService.IssuePasswordResetByUsername")
+	defer span.End()
+
+	id, err := s.repo.Auth.GetUserId(ctx, username)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.PasswordReset{}, ErrUserNotFound
+		}
+		logger.FromContext(ctx).Errorf("EmployeeService.IssuePasswordResetByUsername: cannot get user id: %v", err)
+		return entity.PasswordReset{}, ErrCannotIssueResetToken
+	}
+
+	return s.issuePasswordReset(ctx, id, nil)
+}
+func (s *EmployeeService) ResetPassword(ctx context.Context, input entity.ResetPasswordInput) error {
+	ctx, span := tracer.Start(ctx, "EmployeeService.ResetPassword")
+	defer span.End()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("EmployeeService.ResetPassword: cannot hash password: %v", err)
+		return ErrCannotResetPassword
+	}
+	passwordHash := string(hash)
+
+	return inTx(ctx, s.repo, "EmployeeService.ResetPassword", ErrCannotResetPassword, func(repos *repository.Repository) error {
+		id, err := repos.Auth.ConsumePasswordReset(ctx, resetTokenHash(input.Token))
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrInvalidResetToken
+			}
+			logger.FromContext(ctx).Errorf("EmployeeService.ResetPassword: cannot consume reset token: %v", err)
+			return ErrCannotResetPassword
+		}
+
+		_, err = repos.Auth.UpdateEmployee(ctx, id, entity.EditEmployeeInput{}, &passwordHash)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrInvalidResetToken
+			}
+			logger.FromContext(ctx).Errorf("EmployeeService.ResetPassword: cannot update employee: %v", err)
+			return ErrCannotResetPassword
+		}
+
+		return nil
+	})
+}
+
+func (s *EmployeeService) issuePasswordReset(ctx context.Context, id string, createdBy *string) (entity.PasswordReset, error) {
+	token, err := randomHex(32)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("EmployeeService.issuePasswordReset: cannot generate token: %v", err)
+		return entity.PasswordReset{}, ErrCannotIssueResetToken
+	}
+
+	reset := entity.PasswordReset{
+		EmployeeId: id,
+		Token:      token,
+		ExpiresAt:  time.Now().UTC().Add(s.resetTokenTTL),
+	}
+	err = s.repo.Auth.CreatePasswordReset(ctx, id, resetTokenHash(token), createdBy, reset.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.PasswordReset{}, ErrUserNotFound
+		}
+		logger.FromContext(ctx).Errorf("EmployeeService.issuePasswordReset: cannot create reset token: %v", err)
+		return entity.PasswordReset{}, ErrCannotIssueResetToken
+	}
+
+	return reset, nil
+}
+
+// resetTokenHash is what gets stored, so a leaked table cannot be replayed.
+func resetTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// checkCanCreateEmployee allows anyone to register when self sign-up is enabled. Otherwise only a responsible who
+// manages roles in at least one organization can create accounts.
+func (s *EmployeeService) checkCanCreateEmployee(ctx context.Context, userId *string) error {
+	if s.selfSignUp {
+		return nil
+	}
+	if userId == nil {
+		return ErrSignUpDisabled
+	}
+
+	members, err := userMemberships(ctx, s.repo, *userId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrNotEnoughPermissions
+		}
+		logger.FromContext(ctx).Errorf("EmployeeService.checkCanCreateEmployee: cannot get user memberships: %v", err)
+		return ErrCannotCreateUser
+	}
+	for _, member := range members {
+		if slices.Contains(rolePermissions[member.Role], entity.PermissionRoleManage) {
+			return nil
+		}
+	}
+
+	return ErrNotEnoughPermissions
+}
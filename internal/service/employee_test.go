@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCheckCanCreateEmployee(t *testing.T) {
+	userId := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name       string
+		selfSignUp bool
+		userId     *string
+		wantErr    error
+	}{
+		{name: "self sign-up without token", selfSignUp: true, userId: nil},
+		{name: "self sign-up with token", selfSignUp: true, userId: &userId},
+		{name: "sign-up disabled without token", selfSignUp: false, userId: nil, wantErr: ErrSignUpDisabled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &EmployeeService{selfSignUp: tt.selfSignUp}
+			if err := s.checkCanCreateEmployee(context.Background(), tt.userId); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkCanCreateEmployee() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
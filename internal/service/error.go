@@ -2,22 +2,186 @@ package service
 
 import (
 	"fmt"
+	"net/http"
 )
 
 var (
-	ErrUserNotFound    = fmt.Errorf("user not found")
-	ErrCannotGetUserId = fmt.Errorf("cannot get user id")
+	ErrUserNotFound    = newError("USER_NOT_FOUND", http.StatusNotFound, "user not found")
+	ErrCannotGetUserId = newError("CANNOT_GET_USER_ID", http.StatusInternalServerError, "cannot get user id")
 
-	ErrOrganizationNotFound     = fmt.Errorf("organization not found")
-	ErrUserOrganizationNotFound = fmt.Errorf("user organization not found")
+	ErrUserAlreadyExists = newError("USER_ALREADY_EXISTS", http.StatusConflict, "user already exists")
+	ErrCannotCreateUser  = newError("CANNOT_CREATE_USER", http.StatusInternalServerError, "cannot create user")
+	ErrSignUpDisabled    = newError("SIGN_UP_DISABLED", http.StatusUnauthorized, "self sign-up is disabled, sign in to create employees")
+	ErrCannotGetUser     = newError("CANNOT_GET_USER", http.StatusInternalServerError, "cannot get user")
+	ErrCannotUpdateUser  = newError("CANNOT_UPDATE_USER", http.StatusInternalServerError, "cannot edit user")
+	ErrCannotDeleteUser  = newError("CANNOT_DELETE_USER", http.StatusInternalServerError, "cannot delete user")
 
-	ErrTenderNotFound     = fmt.Errorf("tender not found")
-	ErrCannotGetTender    = fmt.Errorf("cannot get tender")
-	ErrCannotUpdateTender = fmt.Errorf("cannot edit tender")
+	ErrInvalidResetToken     = newError("INVALID_RESET_TOKEN", http.StatusBadRequest, "password reset token is invalid or expired")
+	ErrCannotIssueResetToken = newError("CANNOT_ISSUE_RESET_TOKEN", http.StatusInternalServerError, "cannot issue password reset token")
+	ErrCannotResetPassword   = newError("CANNOT_RESET_PASSWORD", http.StatusInternalServerError, "cannot reset password")
 
-	ErrBidNotFound     = fmt.Errorf("bid not found")
-	ErrCannotGetBid    = fmt.Errorf("cannot get bid")
-	ErrCannotUpdateBid = fmt.Errorf("cannot edit bid")
+	ErrInvalidCredentials = newError("INVALID_CREDENTIALS", http.StatusUnauthorized, "invalid username or password")
+	ErrCannotSignToken    = newError("CANNOT_SIGN_TOKEN", http.StatusInternalServerError, "cannot sign token")
+	ErrInvalidToken       = newError("INVALID_TOKEN", http.StatusUnauthorized, "invalid token")
+	ErrTokenExpired       = newError("TOKEN_EXPIRED", http.StatusUnauthorized, "token expired")
 
-	ErrNotEnoughPermissions = fmt.Errorf("not enough permissions")
+	ErrOrganizationNotFound     = newError("ORGANIZATION_NOT_FOUND", http.StatusNotFound, "organization not found")
+	ErrUserOrganizationNotFound = newError("USER_ORGANIZATION_NOT_FOUND", http.StatusForbidden, "user organization not found")
+	ErrCannotCreateOrganization = newError("CANNOT_CREATE_ORGANIZATION", http.StatusInternalServerError, "cannot create organization")
+	ErrCannotGetOrganization    = newError("CANNOT_GET_ORGANIZATION", http.StatusInternalServerError, "cannot get organization")
+	ErrCannotUpdateOrganization = newError("CANNOT_UPDATE_ORGANIZATION", http.StatusInternalServerError, "cannot edit organization")
+	ErrCannotDeleteOrganization = newError("CANNOT_DELETE_ORGANIZATION", http.StatusInternalServerError, "cannot delete organization")
+	ErrOrganizationHasTenders   = newError("ORGANIZATION_HAS_TENDERS", http.StatusConflict, "organization has tenders")
+
+	ErrMemberNotFound      = newError("MEMBER_NOT_FOUND", http.StatusNotFound, "organization member not found")
+	ErrMemberAlreadyExists = newError("MEMBER_ALREADY_EXISTS", http.StatusConflict, "organization member already exists")
+	ErrCannotGetMembers    = newError("CANNOT_GET_MEMBERS", http.StatusInternalServerError, "cannot get organization members")
+	ErrCannotUpdateMember  = newError("CANNOT_UPDATE_MEMBER", http.StatusInternalServerError, "cannot edit organization member")
+	ErrLastOwner           = newError("LAST_OWNER", http.StatusConflict, "organization must have at least one owner")
+
+	ErrTenderNotFound     = newError("TENDER_NOT_FOUND", http.StatusNotFound, "tender not found")
+	ErrCannotCreateTender = newError("CANNOT_CREATE_TENDER", http.StatusInternalServerError, "cannot create tender")
+	ErrCannotGetTender    = newError("CANNOT_GET_TENDER", http.StatusInternalServerError, "cannot get tender")
+	ErrCannotUpdateTender = newError("CANNOT_UPDATE_TENDER", http.StatusInternalServerError, "cannot edit tender")
+	ErrTenderClosed       = newError("TENDER_CLOSED", http.StatusBadRequest, "cannot edit closed tender")
+
+	ErrInvalidDeadline          = newError("INVALID_DEADLINE", http.StatusBadRequest, "submission deadline must be in the future")
+	ErrSubmissionDeadlinePassed = newError("SUBMISSION_DEADLINE_PASSED", http.StatusBadRequest, "submission deadline has passed")
+	ErrInvalidQuestionDeadline  = newError("INVALID_QUESTION_DEADLINE", http.StatusBadRequest, "question deadline cannot be later than submission deadline")
+
+	ErrInvalidAmount      = newError("INVALID_AMOUNT", http.StatusBadRequest, "amount must be positive with at most 2 decimal places")
+	ErrCurrencyRequired   = newError("CURRENCY_REQUIRED", http.StatusBadRequest, "amount and currency must be set together")
+	ErrCurrencyMismatch   = newError("CURRENCY_MISMATCH", http.StatusBadRequest, "bid currency does not match tender currency")
+	ErrPriceExceedsBudget = newError("PRICE_EXCEEDS_BUDGET", http.StatusBadRequest, "bid price exceeds tender budget")
+
+	ErrTenderNotSealed   = newError("TENDER_NOT_SEALED", http.StatusConflict, "tender bids are not sealed")
+	ErrBidsAlreadyOpened = newError("BIDS_ALREADY_OPENED", http.StatusConflict, "tender bids are already opened")
+	ErrBidsSealed        = newError("BIDS_SEALED", http.StatusConflict, "tender bids are sealed until the submission deadline")
+
+	ErrBidNotFound     = newError("BID_NOT_FOUND", http.StatusNotFound, "bid not found")
+	ErrCannotCreateBid = newError("CANNOT_CREATE_BID", http.StatusInternalServerError, "cannot create bid")
+	ErrCannotGetBid    = newError("CANNOT_GET_BID", http.StatusInternalServerError, "cannot get bid")
+	ErrCannotUpdateBid = newError("CANNOT_UPDATE_BID", http.StatusInternalServerError, "cannot edit bid")
+	ErrBidCanceled     = newError("BID_CANCELED", http.StatusBadRequest, "cannot edit canceled bid")
+
+	ErrStatusUnchanged = newError("STATUS_UNCHANGED", http.StatusBadRequest, "impossible change the status to the same")
+	ErrStatusReverted  = newError("STATUS_REVERTED", http.StatusBadRequest, "impossible change the status to the previous one")
+
+	ErrBidAlreadyDecided        = newError("BID_ALREADY_DECIDED", http.StatusConflict, "decision on the bid has already been made")
+	ErrDecisionAlreadySubmitted = newError("DECISION_ALREADY_SUBMITTED", http.StatusConflict, "user has already submitted a decision on the bid")
+
+	ErrLotNotFound            = newError("LOT_NOT_FOUND", http.StatusNotFound, "lot not found")
+	ErrCannotGetLot           = newError("CANNOT_GET_LOT", http.StatusInternalServerError, "cannot get lot")
+	ErrCannotUpdateLot        = newError("CANNOT_UPDATE_LOT", http.StatusInternalServerError, "cannot edit lot")
+	ErrLotClosed              = newError("LOT_CLOSED", http.StatusConflict, "lot is already awarded or cancelled")
+	ErrLotRequired            = newError("LOT_REQUIRED", http.StatusBadRequest, "lot must be specified for a bid on several lots")
+	ErrDuplicateLot           = newError("DUPLICATE_LOT", http.StatusBadRequest, "bid cannot target the same lot twice")
+	ErrLotBudgetExceedsTender = newError("LOT_BUDGET_EXCEEDS_TENDER", http.StatusBadRequest, "total lot budget exceeds tender budget")
+
+	ErrQuestionNotFound       = newError("QUESTION_NOT_FOUND", http.StatusNotFound, "question not found")
+	ErrCannotCreateQuestion   = newError("CANNOT_CREATE_QUESTION", http.StatusInternalServerError, "cannot create question")
+	ErrCannotGetQuestion      = newError("CANNOT_GET_QUESTION", http.StatusInternalServerError, "cannot get question")
+	ErrCannotAnswerQuestion   = newError("CANNOT_ANSWER_QUESTION", http.StatusInternalServerError, "cannot answer question")
+	ErrTenderNotPublished     = newError("TENDER_NOT_PUBLISHED", http.StatusConflict, "questions can be asked only on published tenders")
+	ErrQuestionDeadlinePassed = newError("QUESTION_DEADLINE_PASSED", http.StatusConflict, "question deadline has passed")
+	ErrOwnTenderQuestion      = newError("OWN_TENDER_QUESTION", http.StatusForbidden, "tender organization members cannot ask questions on own tender")
+
+	ErrCannotGetEvaluation    = newError("CANNOT_GET_EVALUATION", http.StatusInternalServerError, "cannot get evaluation")
+	ErrCannotUpdateEvaluation = newError("CANNOT_UPDATE_EVALUATION", http.StatusInternalServerError, "cannot update evaluation")
+	ErrDuplicateCriterion     = newError("DUPLICATE_CRITERION", http.StatusConflict, "criterion names must be unique within a tender")
+	ErrCriteriaInUse          = newError("CRITERIA_IN_USE", http.StatusConflict, "criteria cannot be changed after scoring has started")
+	ErrCriteriaNotSet         = newError("CRITERIA_NOT_SET", http.StatusBadRequest, "tender has no evaluation criteria")
+	ErrCriterionNotFound      = newError("CRITERION_NOT_FOUND", http.StatusNotFound, "criterion not found")
+	ErrScoreOutOfRange        = newError("SCORE_OUT_OF_RANGE", http.StatusBadRequest, "score exceeds criterion max score")
+	ErrBidNotScorable         = newError("BID_NOT_SCORABLE", http.StatusBadRequest, "only published bids can be scored")
+
+	ErrAttachmentNotFound       = newError("ATTACHMENT_NOT_FOUND", http.StatusNotFound, "attachment not found")
+	ErrCannotGetAttachment      = newError("CANNOT_GET_ATTACHMENT", http.StatusInternalServerError, "cannot get attachment")
+	ErrCannotUploadAttachment   = newError("CANNOT_UPLOAD_ATTACHMENT", http.StatusInternalServerError, "cannot upload attachment")
+	ErrCannotDeleteAttachment   = newError("CANNOT_DELETE_ATTACHMENT", http.StatusInternalServerError, "cannot delete attachment")
+	ErrAttachmentTooLarge       = newError("ATTACHMENT_TOO_LARGE", http.StatusRequestEntityTooLarge, "attachment exceeds maximum file size")
+	ErrAttachmentTypeNotAllowed = newError("ATTACHMENT_TYPE_NOT_ALLOWED", http.StatusUnsupportedMediaType, "attachment file type is not allowed")
+
+	ErrWebhookNotFound      = newError("WEBHOOK_NOT_FOUND", http.StatusNotFound, "webhook not found")
+	ErrWebhookUrlNotAllowed = newError("WEBHOOK_URL_NOT_ALLOWED", http.StatusBadRequest, "webhook url must use https and point to a public address")
+	ErrCannotCreateWebhook  = newError("CANNOT_CREATE_WEBHOOK", http.StatusInternalServerError, "cannot create webhook")
+	ErrCannotGetWebhook     = newError("CANNOT_GET_WEBHOOK", http.StatusInternalServerError, "cannot get webhook")
+	ErrCannotDeleteWebhook  = newError("CANNOT_DELETE_WEBHOOK", http.StatusInternalServerError, "cannot delete webhook")
+	ErrCannotDeliverWebhook = newError("CANNOT_DELIVER_WEBHOOK", http.StatusInternalServerError, "cannot deliver webhooks")
+
+	ErrCannotRelayEvents = newError("CANNOT_RELAY_EVENTS", http.StatusInternalServerError, "cannot relay events")
+	ErrCannotSubscribe   = newError("CANNOT_SUBSCRIBE", http.StatusInternalServerError, "cannot subscribe to events")
+	ErrEventsClosed      = newError("EVENTS_CLOSED", http.StatusServiceUnavailable, "event stream is shutting down")
+
+	ErrVersionNotFound = newError("VERSION_NOT_FOUND", http.StatusNotFound, "version not found")
+	ErrVersionConflict = newError("VERSION_CONFLICT", http.StatusConflict, "version conflict")
+	ErrInvalidRollback = newError("INVALID_ROLLBACK_VERSION", http.StatusBadRequest, "cannot roll back to the current and higher version")
+
+	ErrInvalidCursor = newError("INVALID_CURSOR", http.StatusBadRequest, "invalid cursor")
+
+	ErrNotReady = newError("NOT_READY", http.StatusServiceUnavailable, "service is not ready")
+
+	ErrNotEnoughPermissions = newError("NOT_ENOUGH_PERMISSIONS", http.StatusForbidden, "not enough permissions")
 )
+
+type Error struct {
+	Code    string
+	Message string
+	Status  int
+	Details map[string]any
+}
+
+func newError(code string, status int, message string) *Error {
+	return &Error{
+		Code:    code,
+		Message: message,
+		Status:  status,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+func (e *Error) WithDetails(details map[string]any) *Error {
+	err := *e
+	err.Details = details
+	return &err
+}
+
+type VersionConflictError struct {
+	CurrentVersion int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%v, current version is %d", ErrVersionConflict, e.CurrentVersion)
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict.WithDetails(map[string]any{"currentVersion": e.CurrentVersion})
+}
+
+func tenderClosedError(tenderId string) error {
+	return ErrTenderClosed.WithDetails(map[string]any{"tenderId": tenderId})
+}
+
+func bidCanceledError(bidId string) error {
+	return ErrBidCanceled.WithDetails(map[string]any{"bidId": bidId})
+}
+
+func statusUnchangedError(status string) error {
+	return ErrStatusUnchanged.WithDetails(map[string]any{"status": status})
+}
+
+func statusRevertedError(current string, status string) error {
+	return ErrStatusReverted.WithDetails(map[string]any{"currentStatus": current, "status": status})
+}
+
+func invalidRollbackError(currentVersion int, version int) error {
+	return ErrInvalidRollback.WithDetails(map[string]any{"currentVersion": currentVersion, "version": version})
+}
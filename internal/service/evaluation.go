@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"tender-service/internal/entity"
+	"tender-service/internal/logger"
+	"tender-service/internal/repository"
+	"tender-service/internal/repository/repoerrs"
+)
+
+const defaultMaxScore = 10
+
+type EvaluationService struct {
+	repo *repository.Repository
+}
+
+func NewEvaluationService(repo *repository.Repository) *EvaluationService {
+	return &EvaluationService{repo: repo}
+}
+
+func (s *EvaluationService) GetCriteria(ctx context.Context, tenderId string, userId string) ([]entity.Criterion, error) {
+	ctx, span := tracer.Start(ctx, "EvaluationService.GetCriteria")
+	defer span.End()
+
+	tender, err := s.getTender(ctx, s.repo, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPermission(ctx, s.repo, userId, tender.OrganizationId, entity.PermissionTenderView); err != nil {
+		return nil, err
+	}
+
+	criteria, err := s.repo.Evaluation.GetCriteria(ctx, tenderId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("EvaluationService.GetCriteria: cannot get criteria: %v", err)
+		return nil, ErrCannotGetEvaluation
+	}
+
+	return criteria, nil
+}
+func (s *EvaluationService) SetCriteria(ctx context.Context, tenderId string, userId string, input entity.SetCriteriaInput) ([]entity.Criterion, error) {
+	ctx, span := tracer.Start(ctx, "EvaluationService.SetCriteria")
+	defer span.End()
+
+	criteria := make([]entity.Criterion, 0, len(input.Criteria))
+	names := make(map[string]struct{}, len(input.Criteria))
+	for _, c := range input.Criteria {
+		if _, ok := names[c.Name]; ok {
+			return nil, ErrDuplicateCriterion
+		}
+		names[c.Name] = struct{}{}
+
+		maxScore := c.MaxScore
+		if maxScore == 0 {
+			maxScore = defaultMaxScore
+		}
+		criteria = append(criteria, entity.Criterion{
+			Name:        c.Name,
+			Description: c.Description,
+			Weight:      c.Weight,
+			MaxScore:    maxScore,
+		})
+	}
+
+	err := inTx(ctx, s.repo, "EvaluationService.SetCriteria", ErrCannotUpdateEvaluation, func(repos *repository.Repository) error {
+		if err := repos.Tender.LockTender(ctx, tenderId); err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrTenderNotFound
+			}
+			logger.FromContext(ctx).Errorf("EvaluationService.SetCriteria: cannot lock tender: %v", err)
+			return ErrCannotUpdateTender
+		}
+		tender, err := s.getTender(ctx, repos, tenderId)
+		if err != nil {
+			return err
+		}
+		if err := checkPermission(ctx, repos, userId, tender.OrganizationId, entity.PermissionTenderEdit); err != nil {
+			return err
+		}
+		if tender.Status == "Closed" {
+			return tenderClosedError(tender.Id)
+		}
+
+		scored, err := repos.Evaluation.TenderHasScores(ctx, tenderId)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("EvaluationService.SetCriteria: cannot check scores: %v", err)
+			return ErrCannotUpdateEvaluation
+		}
+		if scored {
+			return ErrCriteriaInUse
+		}
+
+		criteria, err = repos.Evaluation.ReplaceCriteria(ctx, tenderId, criteria)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrAlreadyExists) {
+				return ErrDuplicateCriterion
+			}
+			logger.FromContext(ctx).Errorf("EvaluationService.SetCriteria: cannot replace criteria: %v", err)
+			return ErrCannotUpdateEvaluation
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return criteria, nil
+}
+func (s *EvaluationService) SubmitScores(ctx context.Context, bidId string, userId string, input entity.SubmitScoresInput) ([]entity.BidScore, error) {
+	ctx, span := tracer.Start(ctx, "EvaluationService.SubmitScores")
+	defer span.End()
+
+	var scores []entity.BidScore
+	err := inTx(ctx, s.repo, "EvaluationService.SubmitScores", ErrCannotUpdateEvaluation, func(repos *repository.Repository) error {
+		bid, err := repos.Bid.GetBidById(ctx, bidId)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrBidNotFound
+			}
+			logger.FromContext(ctx).Errorf("EvaluationService.SubmitScores: cannot get bid: %v", err)
+			return ErrCannotGetBid
+		}
+		if bid.Status != "Published" {
+			return ErrBidNotScorable
+		}
+
+		tender, err := s.getTender(ctx, repos, bid.TenderId)
+		if err != nil {
+			return err
+		}
+		if err := checkPermission(ctx, repos, userId, tender.OrganizationId, entity.PermissionBidScore); err != nil {
+			return err
+		}
+		if tender.Status == "Closed" {
+			return tenderClosedError(tender.Id)
+		}
+		if bidsSealed(tender) {
+			return ErrBidsSealed
+		}
+
+		criteria, err := repos.Evaluation.GetCriteria(ctx, tender.Id)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("EvaluationService.SubmitScores: cannot get criteria: %v", err)
+			return ErrCannotGetEvaluation
+		}
+		byId := make(map[string]entity.Criterion, len(criteria))
+		for _, c := range criteria {
+			byId[c.Id] = c
+		}
+
+		for _, in := range input.Scores {
+			criterion, ok := byId[in.CriterionId]
+			if !ok {
+				return ErrCriterionNotFound
+			}
+			if *in.Score > criterion.MaxScore {
+				return ErrScoreOutOfRange
+			}
+
+			score, err := repos.Evaluation.SaveBidScore(ctx, entity.BidScore{
+				BidId:       bidId,
+				CriterionId: criterion.Id,
+				UserId:      userId,
+				Score:       *in.Score,
+				Comment:     in.Comment,
+				BidVersion:  bid.Version,
+			})
+			if err != nil {
+				logger.FromContext(ctx).Errorf("EvaluationService.SubmitScores: cannot save score: %v", err)
+				return ErrCannotUpdateEvaluation
+			}
+			scores = append(scores, score)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return scores, nil
+}
+func (s *EvaluationService) GetBidScores(ctx context.Context, bidId string, userId string) ([]entity.BidScore, error) {
+	ctx, span := tracer.Start(ctx, "EvaluationService.GetBidScores")
+	defer span.End()
+
+	if _, err := s.getScoredBid(ctx, bidId, userId); err != nil {
+		return nil, err
+	}
+
+	scores, err := s.repo.Evaluation.GetBidScores(ctx, bidId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("EvaluationService.GetBidScores: cannot get scores: %v", err)
+		return nil, ErrCannotGetEvaluation
+	}
+
+	return scores, nil
+}
+func (s *EvaluationService) GetBidScoreHistory(ctx context.Context, bidId string, userId string) ([]entity.BidScore, error) {
+	ctx, span := tracer.Start(ctx, "EvaluationService.GetBidScoreHistory")
+	defer span.End()
+
+	if _, err := s.getScoredBid(ctx, bidId, userId); err != nil {
+		return nil, err
+	}
+
+	scores, err := s.repo.Evaluation.GetBidScoreHistory(ctx, bidId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("EvaluationService.GetBidScoreHistory: cannot get score history: %v", err)
+		return nil, ErrCannotGetEvaluation
+	}
+
+	return scores, nil
+}
+func (s *EvaluationService) GetTenderEvaluation(ctx context.Context, tenderId string, userId string) (entity.TenderEvaluation, error) {
+	ctx, span := tracer.Start(ctx, "EvaluationService.GetTenderEvaluation")
+	defer span.End()
+
+	tender, err := s.getTender(ctx, s.repo, tenderId)
+	if err != nil {
+		return entity.TenderEvaluation{}, err
+	}
+	if err := checkPermission(ctx, s.repo, userId, tender.OrganizationId, entity.PermissionTenderView); err != nil {
+		return entity.TenderEvaluation{}, err
+	}
+	if bidsSealed(tender) {
+		return entity.TenderEvaluation{}, ErrBidsSealed
+	}
+
+	criteria, err := s.repo.Evaluation.GetCriteria(ctx, tenderId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("EvaluationService.GetTenderEvaluation: cannot get criteria: %v", err)
+		return entity.TenderEvaluation{}, ErrCannotGetEvaluation
+	}
+	if len(criteria) == 0 {
+		return entity.TenderEvaluation{}, ErrCriteriaNotSet
+	}
+
+	bids, err := s.repo.Bid.GetPublishedBidsForTender(ctx, tenderId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("EvaluationService.GetTenderEvaluation: cannot get bids: %v", err)
+		return entity.TenderEvaluation{}, ErrCannotGetBid
+	}
+
+	scores, err := s.repo.Evaluation.GetTenderScores(ctx, tenderId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("EvaluationService.GetTenderEvaluation: cannot get scores: %v", err)
+		return entity.TenderEvaluation{}, ErrCannotGetEvaluation
+	}
+
+	return entity.TenderEvaluation{
+		TenderId: tenderId,
+		Criteria: criteria,
+		Bids:     rankBids(criteria, bids, scores),
+	}, nil
+}
+
+func (s *EvaluationService) getTender(ctx context.Context, repo *repository.Repository, tenderId string) (entity.Tender, error) {
+	tender, err := repo.Tender.GetTenderById(ctx, tenderId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.Tender{}, ErrTenderNotFound
+		}
+		logger.FromContext(ctx).Errorf("EvaluationService.getTender: cannot get tender: %v", err)
+		return entity.Tender{}, ErrCannotGetTender
+	}
+
+	return tender, nil
+}
+
+func (s *EvaluationService) getScoredBid(ctx context.Context, bidId string, userId string) (entity.Bid, error) {
+	bid, err := s.repo.Bid.GetBidById(ctx, bidId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.Bid{}, ErrBidNotFound
+		}
+		logger.FromContext(ctx).Errorf("EvaluationService.getScoredBid: cannot get bid: %v", err)
+		return entity.Bid{}, ErrCannotGetBid
+	}
+
+	tender, err := s.getTender(ctx, s.repo, bid.TenderId)
+	if err != nil {
+		return entity.Bid{}, err
+	}
+	if err := checkPermission(ctx, s.repo, userId, tender.OrganizationId, entity.PermissionTenderView); err != nil {
+		return entity.Bid{}, err
+	}
+
+	return bid, nil
+}
+
+func rankBids(criteria []entity.Criterion, bids []entity.Bid, scores []entity.BidScore) []entity.BidEvaluation {
+	type sum struct {
+		total   int
+		scorers int
+	}
+	sums := make(map[string]map[string]sum, len(bids))
+	for _, score := range scores {
+		if sums[score.BidId] == nil {
+			sums[score.BidId] = make(map[string]sum)
+		}
+		cs := sums[score.BidId][score.CriterionId]
+		cs.total += score.Score
+		cs.scorers++
+		sums[score.BidId][score.CriterionId] = cs
+	}
+
+	totalWeight := 0
+	for _, c := range criteria {
+		totalWeight += c.Weight
+	}
+
+	evaluations := make([]entity.BidEvaluation, 0, len(bids))
+	for _, bid := range bids {
+		evaluation := entity.BidEvaluation{Bid: bid}
+		weighted := 0.0
+		for _, c := range criteria {
+			cs := sums[bid.Id][c.Id]
+			average := 0.0
+			if cs.scorers > 0 {
+				average = float64(cs.total) / float64(cs.scorers)
+			}
+			weighted += float64(c.Weight) * average / float64(c.MaxScore)
+			evaluation.Criteria = append(evaluation.Criteria, entity.CriterionScore{
+				CriterionId:  c.Id,
+				Name:         c.Name,
+				Weight:       c.Weight,
+				MaxScore:     c.MaxScore,
+				AverageScore: round2(average),
+				Scorers:      cs.scorers,
+			})
+		}
+		if totalWeight > 0 {
+			evaluation.Total = round2(weighted / float64(totalWeight) * 100)
+		}
+		evaluations = append(evaluations, evaluation)
+	}
+
+	sort.SliceStable(evaluations, func(i, j int) bool {
+		return evaluations[i].Total > evaluations[j].Total
+	})
+	for i := range evaluations {
+		evaluations[i].Rank = i + 1
+		if i > 0 && evaluations[i].Total == evaluations[i-1].Total {
+			evaluations[i].Rank = evaluations[i-1].Rank
+		}
+	}
+
+	return evaluations
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"tender-service/internal/entity"
+	"testing"
+)
+
+func TestRankBids(t *testing.T) {
+	price := entity.Criterion{Id: "price", Name: "Price", Weight: 60, MaxScore: 10}
+	quality := entity.Criterion{Id: "quality", Name: "Quality", Weight: 40, MaxScore: 5}
+	score := func(bidId, criterionId, userId string, value int) entity.BidScore {
+		return entity.BidScore{BidId: bidId, CriterionId: criterionId, UserId: userId, Score: value}
+	}
+
+	type ranked struct {
+		bidId string
+		total float64
+		rank  int
+	}
+
+	tests := []struct {
+		name     string
+		criteria []entity.Criterion
+		bids     []string
+		scores   []entity.BidScore
+		want     []ranked
+	}{
+		{
+			name:     "weighted total normalised by max score",
+			criteria: []entity.Criterion{price, quality},
+			bids:     []string{"a", "b"},
+			scores: []entity.BidScore{
+				score("a", "price", "u1", 10), score("a", "quality", "u1", 5),
+				score("b", "price", "u1", 5), score("b", "quality", "u1", 5),
+			},
+			want: []ranked{{"a", 100, 1}, {"b", 70, 2}},
+		},
+		{
+			name:     "scores of several evaluators are averaged",
+			criteria: []entity.Criterion{price},
+			bids:     []string{"a"},
+			scores:   []entity.BidScore{score("a", "price", "u1", 8), score("a", "price", "u2", 5)},
+			want:     []ranked{{"a", 65, 1}},
+		},
+		{
+			name:     "unscored criteria count as zero",
+			criteria: []entity.Criterion{price, quality},
+			bids:     []string{"a"},
+			scores:   []entity.BidScore{score("a", "price", "u1", 10)},
+			want:     []ranked{{"a", 60, 1}},
+		},
+		{
+			name:     "total is rounded to two decimals",
+			criteria: []entity.Criterion{{Id: "c", Weight: 1, MaxScore: 3}},
+			bids:     []string{"a"},
+			scores:   []entity.BidScore{score("a", "c", "u1", 1)},
+			want:     []ranked{{"a", 33.33, 1}},
+		},
+		{
+			name:     "ties share a rank and the next rank is skipped",
+			criteria: []entity.Criterion{price},
+			bids:     []string{"a", "b", "c"},
+			scores: []entity.BidScore{
+				score("a", "price", "u1", 6), score("b", "price", "u1", 6), score("c", "price", "u1", 9),
+			},
+			want: []ranked{{"c", 90, 1}, {"a", 60, 2}, {"b", 60, 2}},
+		},
+		{
+			name:     "no criteria",
+			criteria: nil,
+			bids:     []string{"a", "b"},
+			want:     []ranked{{"a", 0, 1}, {"b", 0, 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bids := make([]entity.Bid, 0, len(tt.bids))
+			for _, id := range tt.bids {
+				bids = append(bids, entity.Bid{Id: id})
+			}
+
+			got := rankBids(tt.criteria, bids, tt.scores)
+			if len(got) != len(tt.want) {
+				t.Fatalf("rankBids() returned %d bids, want %d", len(got), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if got[i].Bid.Id != want.bidId || got[i].Total != want.total || got[i].Rank != want.rank {
+					t.Errorf("rankBids()[%d] = {%s %v %d}, want {%s %v %d}",
+						i, got[i].Bid.Id, got[i].Total, got[i].Rank, want.bidId, want.total, want.rank)
+				}
+			}
+		})
+	}
+}
+
+func TestRankBidsCriterionAverages(t *testing.T) {
+	criteria := []entity.Criterion{{Id: "c", Name: "Delivery", Weight: 10, MaxScore: 10}}
+	scores := []entity.BidScore{
+		{BidId: "a", CriterionId: "c", UserId: "u1", Score: 7},
+		{BidId: "a", CriterionId: "c", UserId: "u2", Score: 8},
+		{BidId: "a", CriterionId: "c", UserId: "u3", Score: 8},
+	}
+
+	got := rankBids(criteria, []entity.Bid{{Id: "a"}}, scores)
+	if len(got) != 1 || len(got[0].Criteria) != 1 {
+		t.Fatalf("rankBids() = %+v, want one bid with one criterion", got)
+	}
+	criterion := got[0].Criteria[0]
+	if criterion.AverageScore != 7.67 || criterion.Scorers != 3 {
+		t.Errorf("criterion score = {%v %d}, want {7.67 3}", criterion.AverageScore, criterion.Scorers)
+	}
+}
+
+func TestRound2(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  float64
+	}{
+		{value: 0, want: 0},
+		{value: 33.333333, want: 33.33},
+		{value: 66.666666, want: 66.67},
+		{value: -1.235, want: -1.24},
+	}
+
+	for _, tt := range tests {
+		if got := round2(tt.value); got != tt.want {
+			t.Errorf("round2(%v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
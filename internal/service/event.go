@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"tender-service/internal/entity"
+	"tender-service/internal/logger"
+	"tender-service/internal/repository"
+	"tender-service/internal/repository/repoerrs"
+	"time"
+)
+
+type EventService struct {
+	repo          *repository.Repository
+	bufferSize    int
+	membershipTTL time.Duration
+
+	mu            sync.Mutex
+	nextId        int
+	subscriptions map[int]*subscription
+	closed        bool
+}
+
+type subscription struct {
+	userId         string
+	organizationId string
+	members        []entity.OrganizationMember
+	checkedAt      time.Time
+	filter         entity.EventFilter
+	events         chan entity.Event
+}
+
+type eventAudience struct {
+	tenderOrganizationId   string
+	bidAuthorType          string
+	bidAuthorId            string
+	bidAuthorOrganizations []string
+}
+
+func NewEventService(repo *repository.Repository, bufferSize int, membershipTTL time.Duration) *EventService {
+	return &EventService{
+		repo:          repo,
+		bufferSize:    bufferSize,
+		membershipTTL: membershipTTL,
+		subscriptions: make(map[int]*subscription),
+	}
+}
+
+func (s *EventService) Subscribe(ctx context.Context, userId string, filter entity.EventFilter) (<-chan entity.Event, func(), error) {
+	ctx, span := tracer.Start(ctx, "EventService.Subscribe")
+	defer span.End()
+
+	members, err := userMemberships(ctx, s.repo, userId)
+	if err != nil && !errors.Is(err, repoerrs.ErrNotFound) {
+		logger.FromContext(ctx).Errorf("EventService.Subscribe: cannot get user memberships: %v", err)
+		return nil, nil, ErrCannotSubscribe
+	}
+	organizationId, _ := OrganizationIdFromContext(ctx)
+	sub := &subscription{
+		userId:         userId,
+		organizationId: organizationId,
+		members:        members,
+		checkedAt:      time.Now(),
+		filter:         filter,
+		events:         make(chan entity.Event, s.bufferSize),
+	}
+
+	for _, tenderId := range filter.TenderIds {
+		if err := s.checkTender(ctx, sub, tenderId); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, bidId := range filter.BidIds {
+		if err := s.checkBid(ctx, sub, bidId); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, nil, ErrEventsClosed
+	}
+	id := s.nextId
+	s.nextId++
+	s.subscriptions[id] = sub
+
+	return sub.events, func() { s.unsubscribe(id) }, nil
+}
+func (s *EventService) Listen(ctx context.Context) error {
+	return s.repo.Event.ListenEvents(ctx, func(event entity.Event) {
+		s.broadcast(ctx, event)
+	})
+}
+func (s *EventService) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for id, sub := range s.subscriptions {
+		close(sub.events)
+		delete(s.subscriptions, id)
+	}
+}
+
+func (s *EventService) Name() string {
+	return "notify"
+}
+
+func (s *EventService) Handle(ctx context.Context, event entity.Event) error {
+	ctx, span := tracer.Start(ctx, "EventService.Handle")
+	defer span.End()
+
+	return s.repo.Event.NotifyEvent(ctx, event)
+}
+
+func (s *EventService) unsubscribe(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sub, ok := s.subscriptions[id]; ok {
+		close(sub.events)
+		delete(s.subscriptions, id)
+	}
+}
+
+func (s *EventService) broadcast(ctx context.Context, event entity.Event) {
+	s.mu.Lock()
+	empty := len(s.subscriptions) == 0
+	s.mu.Unlock()
+	if empty {
+		return
+	}
+
+	audience, err := s.eventAudience(ctx, event)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("EventService.broadcast: cannot resolve event %s audience: %v", event.Id, err)
+		return
+	}
+	s.refreshMemberships(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, sub := range s.subscriptions {
+		if !sub.wants(event, audience) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			logger.FromContext(ctx).Warnf("EventService.broadcast: subscriber of user %s is too slow, closing stream", sub.userId)
+			close(sub.events)
+			delete(s.subscriptions, id)
+		}
+	}
+}
+
+// refreshMemberships reloads the memberships of subscribers checked more than membershipTTL ago, so a revoked role
+// or a removed responsible stops receiving events within that interval. If the reload fails, the subscriber keeps
+// only the events addressed to it personally until the next successful check.
+func (s *EventService) refreshMemberships(ctx context.Context) {
+	type member struct {
+		userId         string
+		organizationId string
+	}
+	now := time.Now()
+
+	s.mu.Lock()
+	stale := make(map[member]struct{})
+	for _, sub := range s.subscriptions {
+		if now.Sub(sub.checkedAt) >= s.membershipTTL {
+			stale[member{userId: sub.userId, organizationId: sub.organizationId}] = struct{}{}
+		}
+	}
+	s.mu.Unlock()
+
+	for m := range stale {
+		memberCtx := ctx
+		if m.organizationId != "" {
+			memberCtx = WithOrganizationId(ctx, m.organizationId)
+		}
+		members, err := userMemberships(memberCtx, s.repo, m.userId)
+		checked := err == nil || errors.Is(err, repoerrs.ErrNotFound)
+		if !checked {
+			logger.FromContext(ctx).Errorf("EventService.refreshMemberships: cannot get user %s memberships: %v", m.userId, err)
+		}
+
+		s.mu.Lock()
+		for _, sub := range s.subscriptions {
+			if sub.userId != m.userId || sub.organizationId != m.organizationId {
+				continue
+			}
+			if checked {
+				sub.members = members
+				sub.checkedAt = now
+			} else {
+				sub.members = nil
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *EventService) eventAudience(ctx context.Context, event entity.Event) (eventAudience, error) {
+	tender, err := s.repo.Tender.GetTenderById(ctx, event.Data.TenderId)
+	if err != nil {
+		return eventAudience{}, fmt.Errorf("GetTenderById: %v", err)
+	}
+	if event.Data.BidId == "" {
+		return eventAudience{tenderOrganizationId: tender.OrganizationId}, nil
+	}
+
+	bid, err := s.repo.Bid.GetBidById(ctx, event.Data.BidId)
+	if err != nil {
+		return eventAudience{}, fmt.Errorf("GetBidById: %v", err)
+	}
+	return s.bidAudience(ctx, tender, bid)
+}
+
+func (s *EventService) bidAudience(ctx context.Context, tender entity.Tender, bid entity.Bid) (eventAudience, error) {
+	audience := eventAudience{
+		tenderOrganizationId: tender.OrganizationId,
+		bidAuthorType:        bid.AuthorType,
+		bidAuthorId:          bid.AuthorId,
+	}
+	if bid.AuthorType == "Organization" {
+		members, err := s.repo.Auth.GetUserMemberships(ctx, bid.AuthorId)
+		if err != nil && !errors.Is(err, repoerrs.ErrNotFound) {
+			return eventAudience{}, fmt.Errorf("GetUserMemberships: %v", err)
+		}
+		audience.bidAuthorOrganizations = organizationIds(members)
+	}
+	return audience, nil
+}
+
+func (s *EventService) checkTender(ctx context.Context, sub *subscription, tenderId string) error {
+	tender, err := s.repo.Tender.GetTenderById(ctx, tenderId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrTenderNotFound
+		}
+		logger.FromContext(ctx).Errorf("EventService.checkTender: cannot get tender: %v", err)
+		return ErrCannotGetTender
+	}
+	if tender.Status != "Published" && !sub.hasPermission(tender.OrganizationId, entity.PermissionTenderView) {
+		return ErrNotEnoughPermissions
+	}
+	return nil
+}
+
+func (s *EventService) checkBid(ctx context.Context, sub *subscription, bidId string) error {
+	bid, err := s.repo.Bid.GetBidById(ctx, bidId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrBidNotFound
+		}
+		logger.FromContext(ctx).Errorf("EventService.checkBid: cannot get bid: %v", err)
+		return ErrCannotGetBid
+	}
+	tender, err := s.repo.Tender.GetTenderById(ctx, bid.TenderId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrTenderNotFound
+		}
+		logger.FromContext(ctx).Errorf("EventService.checkBid: cannot get tender: %v", err)
+		return ErrCannotGetTender
+	}
+
+	audience, err := s.bidAudience(ctx, tender, bid)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("EventService.checkBid: cannot resolve bid audience: %v", err)
+		return ErrCannotSubscribe
+	}
+	if !sub.isBidAuthor(audience) && !(bid.Status != "Created" && sub.hasPermission(tender.OrganizationId, entity.PermissionTenderView)) {
+		return ErrNotEnoughPermissions
+	}
+	return nil
+}
+
+func (sub *subscription) wants(event entity.Event, audience eventAudience) bool {
+	responsible := sub.hasPermission(audience.tenderOrganizationId, entity.PermissionTenderView)
+
+	var visible, involved bool
+	if event.Data.BidId == "" {
+		visible = responsible || event.Data.Status == "Published"
+		involved = responsible
+	} else {
+		visible = sub.isBidAuthor(audience) || (responsible && event.Data.Status != "Created")
+		involved = visible
+	}
+	if !visible {
+		return false
+	}
+
+	if len(sub.filter.TenderIds) == 0 && len(sub.filter.BidIds) == 0 {
+		return involved
+	}
+	return slices.Contains(sub.filter.TenderIds, event.Data.TenderId) ||
+		(event.Data.BidId != "" && slices.Contains(sub.filter.BidIds, event.Data.BidId))
+}
+
+func (sub *subscription) hasPermission(organizationId string, permission string) bool {
+	for _, member := range sub.members {
+		if member.OrganizationId == organizationId && slices.Contains(rolePermissions[member.Role], permission) {
+			return true
+		}
+	}
+	return false
+}
+
+func (sub *subscription) isBidAuthor(audience eventAudience) bool {
+	if audience.bidAuthorId == sub.userId {
+		return true
+	}
+	if audience.bidAuthorType != "Organization" {
+		return false
+	}
+	for _, member := range sub.members {
+		if slices.Contains(audience.bidAuthorOrganizations, member.OrganizationId) {
+			return true
+		}
+	}
+	return false
+}
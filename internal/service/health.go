@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"tender-service/internal/entity"
+	"tender-service/internal/logger"
+	"tender-service/internal/repository"
+	"tender-service/internal/repository/repoerrs"
+	"time"
+)
+
+const readinessTimeout = 2 * time.Second
+
+type HealthService struct {
+	repo          *repository.Repository
+	schemaVersion int
+	draining      atomic.Bool
+}
+
+func NewHealthService(repo *repository.Repository, schemaVersion int) *HealthService {
+	return &HealthService{
+		repo:          repo,
+		schemaVersion: schemaVersion,
+	}
+}
+
+func (s *HealthService) Readiness(ctx context.Context) (entity.Readiness, error) {
+	ctx, span := tracer.Start(ctx, "HealthService.Readiness")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	readiness := entity.Readiness{
+		Status: entity.ReadinessReady,
+		Checks: map[string]string{
+			"shutdown":   entity.HealthStatusOk,
+			"database":   entity.HealthStatusOk,
+			"migrations": entity.HealthStatusOk,
+		},
+	}
+	fail := func(check string, reason string) {
+		readiness.Status = entity.ReadinessNotReady
+		readiness.Checks[check] = reason
+	}
+
+	if s.draining.Load() {
+		fail("shutdown", "server is shutting down")
+	}
+
+	if err := s.repo.Health.Ping(ctx); err != nil {
+		logger.FromContext(ctx).Errorf("HealthService.Readiness: cannot ping database: %v", err)
+		fail("database", entity.HealthStatusFail)
+		fail("migrations", "database is unavailable")
+	} else if err := s.checkMigrations(ctx); err != nil {
+		fail("migrations", err.Error())
+	}
+
+	if readiness.Status != entity.ReadinessReady {
+		return readiness, ErrNotReady
+	}
+	return readiness, nil
+}
+
+func (s *HealthService) Drain() {
+	s.draining.Store(true)
+}
+
+func (s *HealthService) checkMigrations(ctx context.Context) error {
+	version, dirty, err := s.repo.Health.GetMigrationVersion(ctx)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return fmt.Errorf("migrations are not applied, expected version %d", s.schemaVersion)
+		}
+		logger.FromContext(ctx).Errorf("HealthService.checkMigrations: cannot get migration version: %v", err)
+		return fmt.Errorf("cannot get migration version")
+	}
+	if dirty {
+		return fmt.Errorf("migration %d is dirty", version)
+	}
+	if version != s.schemaVersion {
+		return fmt.Errorf("migration version is %d, expected %d", version, s.schemaVersion)
+	}
+	return nil
+}
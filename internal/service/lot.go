@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/shopspring/decimal"
+	"tender-service/internal/entity"
+	"tender-service/internal/logger"
+	"tender-service/internal/metrics"
+	"tender-service/internal/repository"
+	"tender-service/internal/repository/repoerrs"
+)
+
+type LotService struct {
+	repo    *repository.Repository
+	metrics *metrics.Metrics
+}
+
+func NewLotService(repo *repository.Repository, metrics *metrics.Metrics) *LotService {
+	return &LotService{
+		repo:    repo,
+		metrics: metrics,
+	}
+}
+
+func (s *LotService) GetLots(ctx context.Context, tenderId string, userId *string) ([]entity.Lot, error) {
+	ctx, span := tracer.Start(ctx, "LotService.GetLots")
+	defer span.End()
+
+	tender, err := s.repo.Tender.GetTenderById(ctx, tenderId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrTenderNotFound
+		}
+		logger.FromContext(ctx).Errorf("LotService.GetLots: cannot get tender: %v", err)
+		return nil, ErrCannotGetTender
+	}
+	if tender.Status != "Published" {
+		if userId == nil {
+			return nil, ErrNotEnoughPermissions
+		}
+		if err := checkPermission(ctx, s.repo, *userId, tender.OrganizationId, entity.PermissionTenderView); err != nil {
+			return nil, err
+		}
+	}
+
+	lots, err := s.repo.Lot.GetLots(ctx, tenderId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("LotService.GetLots: cannot get lots: %v", err)
+		return nil, ErrCannotGetLot
+	}
+
+	return lots, nil
+}
+func (s *LotService) AddLots(ctx context.Context, tenderId string, userId string, input entity.AddLotsInput) ([]entity.Lot, error) {
+	ctx, span := tracer.Start(ctx, "LotService.AddLots")
+	defer span.End()
+
+	var result []entity.Lot
+	err := inTx(ctx, s.repo, "LotService.AddLots", ErrCannotUpdateLot, func(repos *repository.Repository) error {
+		tender, err := lockTender(ctx, repos, tenderId)
+		if err != nil {
+			return err
+		}
+		if err := checkPermission(ctx, repos, userId, tender.OrganizationId, entity.PermissionTenderEdit); err != nil {
+			return err
+		}
+		if tender.Status == "Closed" {
+			return tenderClosedError(tender.Id)
+		}
+
+		existing, err := repos.Lot.GetLots(ctx, tenderId)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("LotService.AddLots: cannot get lots: %v", err)
+			return ErrCannotGetLot
+		}
+
+		lots := newLots(input.Lots, len(existing)+1)
+		if err := checkLotBudgets(tender, append(existing, lots...)); err != nil {
+			return err
+		}
+
+		lots, err = repos.Lot.CreateLots(ctx, tenderId, lots)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("LotService.AddLots: cannot create lots: %v", err)
+			return ErrCannotUpdateLot
+		}
+
+		result = append(existing, lots...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+func (s *LotService) CancelLot(ctx context.Context, tenderId string, lotId string, userId string) (entity.Lot, error) {
+	ctx, span := tracer.Start(ctx, "LotService.CancelLot")
+	defer span.End()
+
+	var result entity.Lot
+	var tenderClosed bool
+	err := inTx(ctx, s.repo, "LotService.CancelLot", ErrCannotUpdateLot, func(repos *repository.Repository) error {
+		tender, err := lockTender(ctx, repos, tenderId)
+		if err != nil {
+			return err
+		}
+		if err := checkPermission(ctx, repos, userId, tender.OrganizationId, entity.PermissionTenderStatus); err != nil {
+			return err
+		}
+
+		lot, err := repos.Lot.GetLotById(ctx, lotId)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrLotNotFound
+			}
+			logger.FromContext(ctx).Errorf("LotService.CancelLot: cannot get lot: %v", err)
+			return ErrCannotGetLot
+		}
+		if lot.TenderId != tenderId {
+			return ErrLotNotFound
+		}
+
+		lot, err = repos.Lot.CloseLot(ctx, lotId, "Cancelled", nil)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrLotClosed
+			}
+			logger.FromContext(ctx).Errorf("LotService.CancelLot: cannot cancel lot: %v", err)
+			return ErrCannotUpdateLot
+		}
+
+		tenderClosed, err = closeTenderIfResolved(ctx, repos, tender)
+		if err != nil {
+			return err
+		}
+
+		result = lot
+		return nil
+	})
+	if err != nil {
+		return entity.Lot{}, err
+	}
+	if tenderClosed {
+		s.metrics.TendersClosed(1)
+	}
+
+	return result, nil
+}
+
+func lockTender(ctx context.Context, repos *repository.Repository, tenderId string) (entity.Tender, error) {
+	if err := repos.Tender.LockTender(ctx, tenderId); err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.Tender{}, ErrTenderNotFound
+		}
+		logger.FromContext(ctx).Errorf("lockTender: cannot lock tender: %v", err)
+		return entity.Tender{}, ErrCannotUpdateTender
+	}
+
+	tender, err := repos.Tender.GetTenderById(ctx, tenderId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.Tender{}, ErrTenderNotFound
+		}
+		logger.FromContext(ctx).Errorf("lockTender: cannot get tender: %v", err)
+		return entity.Tender{}, ErrCannotGetTender
+	}
+
+	return tender, nil
+}
+
+func closeTenderIfResolved(ctx context.Context, repos *repository.Repository, tender entity.Tender) (bool, error) {
+	if tender.Status == "Closed" {
+		return false, nil
+	}
+
+	open, err := repos.Lot.CountOpenLots(ctx, tender.Id)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("closeTenderIfResolved: cannot count open lots: %v", err)
+		return false, ErrCannotGetLot
+	}
+	if open > 0 {
+		return false, nil
+	}
+
+	_, err = repos.Tender.UpdateTenderStatus(ctx, tender.Id, "Closed")
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return false, ErrTenderNotFound
+		}
+		logger.FromContext(ctx).Errorf("closeTenderIfResolved: cannot close tender: %v", err)
+		return false, ErrCannotUpdateTender
+	}
+
+	return true, nil
+}
+
+func newLots(inputs []entity.LotInput, firstNumber int) []entity.Lot {
+	lots := make([]entity.Lot, 0, len(inputs))
+	for i, input := range inputs {
+		lots = append(lots, entity.Lot{
+			Number:      firstNumber + i,
+			Name:        input.Name,
+			Description: input.Description,
+			ServiceType: input.ServiceType,
+			Budget:      input.Budget,
+		})
+	}
+	return lots
+}
+
+func checkLotBudgets(tender entity.Tender, lots []entity.Lot) error {
+	total := decimal.Zero
+	for _, lot := range lots {
+		if lot.Budget == nil {
+			continue
+		}
+		if err := validateAmount(lot.Budget, tender.Currency); err != nil {
+			return err
+		}
+		total = total.Add(*lot.Budget)
+	}
+	if tender.Budget != nil && total.GreaterThan(*tender.Budget) {
+		return ErrLotBudgetExceedsTender
+	}
+	return nil
+}
+
+func checkLotPrice(tender entity.Tender, lot entity.Lot, price *decimal.Decimal, currency *string) error {
+	if err := validateAmount(price, currency); err != nil {
+		return err
+	}
+	if price == nil || lot.Budget == nil {
+		return nil
+	}
+	if tender.Currency == nil || *tender.Currency != *currency {
+		return ErrCurrencyMismatch
+	}
+	if price.GreaterThan(*lot.Budget) {
+		return ErrPriceExceedsBudget
+	}
+	return nil
+}
+
+// editBidLots applies a bid edit to the prices of the bid's lots and checks each price against its lot budget. The
+// lot price of a single-lot bid follows the bid price; a multi-lot bid changes lot prices only through input.Lots.
+// The returned slice holds every lot of the bid with its resulting price.
+func editBidLots(tender entity.Tender, lots []entity.Lot, bidLots []entity.BidLot, input entity.EditBidInput, price *decimal.Decimal, currency *string) ([]entity.BidLot, error) {
+	byId := make(map[string]entity.Lot, len(lots))
+	for _, lot := range lots {
+		byId[lot.Id] = lot
+	}
+
+	prices := make(map[string]*decimal.Decimal, len(input.Lots))
+	for _, in := range input.Lots {
+		if _, ok := prices[in.LotId]; ok {
+			return nil, ErrDuplicateLot
+		}
+		prices[in.LotId] = in.Price
+	}
+	if len(input.Lots) == 0 && len(bidLots) == 1 && input.Price != nil {
+		prices[bidLots[0].LotId] = price
+	}
+
+	result := make([]entity.BidLot, 0, len(bidLots))
+	for _, bidLot := range bidLots {
+		lot, ok := byId[bidLot.LotId]
+		if !ok {
+			return nil, ErrLotNotFound
+		}
+		if lotPrice, ok := prices[bidLot.LotId]; ok {
+			delete(prices, bidLot.LotId)
+			if lot.Status != "Open" {
+				return nil, ErrLotClosed
+			}
+			bidLot.Price = lotPrice
+		}
+		if err := checkLotPrice(tender, lot, bidLot.Price, currency); err != nil {
+			return nil, err
+		}
+		result = append(result, bidLot)
+	}
+	if len(prices) > 0 {
+		return nil, ErrLotNotFound
+	}
+
+	return result, nil
+}
+
+func selectBidLot(bidLots []entity.BidLot, lotId string) (entity.BidLot, error) {
+	if lotId == "" {
+		if len(bidLots) != 1 {
+			return entity.BidLot{}, ErrLotRequired
+		}
+		return bidLots[0], nil
+	}
+	for _, bidLot := range bidLots {
+		if bidLot.LotId == lotId {
+			return bidLot, nil
+		}
+	}
+	return entity.BidLot{}, ErrLotNotFound
+}
+
+func overallDecision(bidLots []entity.BidLot) string {
+	if len(bidLots) == 0 {
+		return ""
+	}
+	approved := false
+	for _, bidLot := range bidLots {
+		if bidLot.Decision == nil {
+			return ""
+		}
+		if *bidLot.Decision == "Approved" {
+			approved = true
+		}
+	}
+	if approved {
+		return "Approved"
+	}
+	return "Rejected"
+}
+
+func decisionsForLot(decisions []entity.BidDecision, lotId string) []entity.BidDecision {
+	var result []entity.BidDecision
+	for _, decision := range decisions {
+		if decision.LotId == lotId {
+			result = append(result, decision)
+		}
+	}
+	return result
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"errors"
+	"github.com/shopspring/decimal"
+	"tender-service/internal/entity"
+	"testing"
+)
+
+func amount(value string) *decimal.Decimal {
+	d := decimal.RequireFromString(value)
+	return &d
+}
+
+func TestEditBidLots(t *testing.T) {
+	usd, eur := "USD", "EUR"
+	tender := entity.Tender{Budget: amount("1000.00"), Currency: &usd}
+	lots := []entity.Lot{
+		{Id: "l1", Budget: amount("600.00"), Status: "Open"},
+		{Id: "l2", Budget: amount("400.00"), Status: "Open"},
+		{Id: "l3", Budget: amount("100.00"), Status: "Awarded"},
+	}
+	single := []entity.BidLot{{BidId: "b1", LotId: "l1", Price: amount("500.00")}}
+	multi := []entity.BidLot{
+		{BidId: "b1", LotId: "l1", Price: amount("500.00")},
+		{BidId: "b1", LotId: "l2", Price: amount("300.00")},
+	}
+
+	tests := []struct {
+		name      string
+		bidLots   []entity.BidLot
+		input     entity.EditBidInput
+		price     *decimal.Decimal
+		currency  *string
+		wantPrice []string
+		wantErr   error
+	}{
+		{
+			name:      "single lot follows bid price",
+			bidLots:   single,
+			input:     entity.EditBidInput{Price: amount("550.00")},
+			price:     amount("550.00"),
+			currency:  &usd,
+			wantPrice: []string{"550"},
+		},
+		{
+			name:     "single lot price above lot budget",
+			bidLots:  single,
+			input:    entity.EditBidInput{Price: amount("700.00")},
+			price:    amount("700.00"),
+			currency: &usd,
+			wantErr:  ErrPriceExceedsBudget,
+		},
+		{
+			name:      "multi lot keeps lot prices on bid price edit",
+			bidLots:   multi,
+			input:     entity.EditBidInput{Price: amount("900.00")},
+			price:     amount("900.00"),
+			currency:  &usd,
+			wantPrice: []string{"500", "300"},
+		},
+		{
+			name:      "multi lot price edit",
+			bidLots:   multi,
+			input:     entity.EditBidInput{Lots: []entity.BidLotInput{{LotId: "l2", Price: amount("350.00")}}},
+			price:     amount("800.00"),
+			currency:  &usd,
+			wantPrice: []string{"500", "350"},
+		},
+		{
+			name:     "multi lot price above lot budget",
+			bidLots:  multi,
+			input:    entity.EditBidInput{Lots: []entity.BidLotInput{{LotId: "l2", Price: amount("450.00")}}},
+			price:    amount("800.00"),
+			currency: &usd,
+			wantErr:  ErrPriceExceedsBudget,
+		},
+		{
+			name:     "currency change rechecks lot prices",
+			bidLots:  multi,
+			input:    entity.EditBidInput{Currency: &eur},
+			price:    amount("800.00"),
+			currency: &eur,
+			wantErr:  ErrCurrencyMismatch,
+		},
+		{
+			name:     "lot outside the bid",
+			bidLots:  single,
+			input:    entity.EditBidInput{Lots: []entity.BidLotInput{{LotId: "l2", Price: amount("100.00")}}},
+			price:    amount("500.00"),
+			currency: &usd,
+			wantErr:  ErrLotNotFound,
+		},
+		{
+			name:    "duplicate lot",
+			bidLots: multi,
+			input: entity.EditBidInput{Lots: []entity.BidLotInput{
+				{LotId: "l1", Price: amount("100.00")},
+				{LotId: "l1", Price: amount("200.00")},
+			}},
+			price:    amount("800.00"),
+			currency: &usd,
+			wantErr:  ErrDuplicateLot,
+		},
+		{
+			name:     "closed lot",
+			bidLots:  []entity.BidLot{{BidId: "b1", LotId: "l3", Price: amount("50.00")}},
+			input:    entity.EditBidInput{Price: amount("60.00")},
+			price:    amount("60.00"),
+			currency: &usd,
+			wantErr:  ErrLotClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := editBidLots(tender, lots, tt.bidLots, tt.input, tt.price, tt.currency)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("editBidLots() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if len(got) != len(tt.wantPrice) {
+				t.Fatalf("editBidLots() returned %d lots, want %d", len(got), len(tt.wantPrice))
+			}
+			for i, bidLot := range got {
+				if bidLot.Price.String() != tt.wantPrice[i] {
+					t.Errorf("editBidLots()[%d].Price = %s, want %s", i, bidLot.Price, tt.wantPrice[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckLotBudgets(t *testing.T) {
+	usd := "USD"
+	lots := []entity.Lot{{Budget: amount("600.00")}, {Budget: amount("400.00")}, {}}
+
+	tests := []struct {
+		name    string
+		budget  *decimal.Decimal
+		wantErr error
+	}{
+		{name: "budget covers lots", budget: amount("1000.00")},
+		{name: "budget lowered below lots", budget: amount("999.99"), wantErr: ErrLotBudgetExceedsTender},
+		{name: "no tender budget", budget: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tender := entity.Tender{Budget: tt.budget, Currency: &usd}
+			if err := checkLotBudgets(tender, lots); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkLotBudgets() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"tender-service/internal/entity"
+	"tender-service/internal/logger"
+	"tender-service/internal/repository"
+	"tender-service/internal/repository/repoerrs"
+)
+
+type OrganizationService struct {
+	repo *repository.Repository
+}
+
+func NewOrganizationService(repo *repository.Repository) *OrganizationService {
+	return &OrganizationService{repo: repo}
+}
+
+func (s *OrganizationService) CreateOrganization(ctx context.Context, userId string, input entity.CreateOrganizationInput) (entity.Organization, error) {
+	ctx, span := tracer.Start(ctx, "OrganizationService.CreateOrganization")
+	defer span.End()
+
+	organization := entity.Organization{
+		Name:        input.Name,
+		Description: input.Description,
+		Type:        input.Type,
+	}
+
+	organization, err := s.repo.Auth.CreateOrganization(ctx, organization, userId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("OrganizationService.CreateOrganization: cannot create organization: %v", err)
+		return entity.Organization{}, ErrCannotCreateOrganization
+	}
+
+	return organization, nil
+}
+func (s *OrganizationService) GetOrganizations(ctx context.Context, limit int, offset int) ([]entity.Organization, error) {
+	ctx, span := tracer.Start(ctx, "OrganizationService.GetOrganizations")
+	defer span.End()
+
+	organizations, err := s.repo.Auth.GetOrganizations(ctx, limit, offset)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("OrganizationService.GetOrganizations: cannot get organizations: %v", err)
+		return nil, ErrCannotGetOrganization
+	}
+
+	return organizations, nil
+}
+func (s *OrganizationService) GetOrganizationById(ctx context.Context, id string) (entity.Organization, error) {
+	ctx, span := tracer.Start(ctx, "OrganizationService.GetOrganizationById")
+	defer span.End()
+
+	organization, err := s.repo.Auth.GetOrganizationById(ctx, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.Organization{}, ErrOrganizationNotFound
+		}
+		logger.FromContext(ctx).Errorf("OrganizationService.GetOrganizationById: cannot get organization: %v", err)
+		return entity.Organization{}, ErrCannotGetOrganization
+	}
+
+	return organization, nil
+}
+func (s *OrganizationService) EditOrganization(ctx context.Context, id string, userId string, input entity.EditOrganizationInput) (entity.Organization, error) {
+	ctx, span := tracer.Start(ctx, "OrganizationService.EditOrganization")
+	defer span.End()
+
+	if err := checkPermission(ctx, s.repo, userId, id, entity.PermissionOrganizationManage); err != nil {
+		return entity.Organization{}, err
+	}
+
+	organization, err := s.repo.Auth.UpdateOrganization(ctx, id, input)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.Organization{}, ErrOrganizationNotFound
+		}
+		logger.FromContext(ctx).Errorf("OrganizationService.EditOrganization: cannot update organization: %v", err)
+		return entity.Organization{}, ErrCannotUpdateOrganization
+	}
+
+	return organization, nil
+}
+func (s *OrganizationService) DeleteOrganization(ctx context.Context, id string, userId string) error {
+	ctx, span := tracer.Start(ctx, "OrganizationService.DeleteOrganization")
+	defer span.End()
+
+	if err := checkPermission(ctx, s.repo, userId, id, entity.PermissionOrganizationManage); err != nil {
+		return err
+	}
+
+	err := s.repo.Auth.DeleteOrganization(ctx, id)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrOrganizationNotFound
+		}
+		if errors.Is(err, repoerrs.ErrHasDependents) {
+			return ErrOrganizationHasTenders
+		}
+		logger.FromContext(ctx).Errorf("OrganizationService.DeleteOrganization: cannot delete organization: %v", err)
+		return ErrCannotDeleteOrganization
+	}
+
+	return nil
+}
+func (s *OrganizationService) AddResponsible(ctx context.Context, organizationId string, userId string, input entity.AddResponsibleInput) ([]entity.OrganizationMember, error) {
+	ctx, span := tracer.Start(ctx, "OrganizationService.AddResponsible")
+	defer span.End()
+
+	if err := checkPermission(ctx, s.repo, userId, organizationId, entity.PermissionRoleManage); err != nil {
+		return nil, err
+	}
+
+	role := input.Role
+	if role == "" {
+		role = entity.RoleViewer
+	}
+
+	err := s.repo.Auth.AddResponsible(ctx, organizationId, input.UserId, role)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrAlreadyExists) {
+			return nil, ErrMemberAlreadyExists
+		}
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		logger.FromContext(ctx).Errorf("OrganizationService.AddResponsible: cannot add responsible: %v", err)
+		return nil, ErrCannotUpdateMember
+	}
+
+	members, err := s.repo.Auth.GetOrganizationMembers(ctx, organizationId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("OrganizationService.AddResponsible: cannot get organization members: %v", err)
+		return nil, ErrCannotGetMembers
+	}
+
+	return members, nil
+}
+func (s *OrganizationService) RemoveResponsible(ctx context.Context, organizationId string, memberId string, userId string) error {
+	ctx, span := tracer.Start(ctx, "OrganizationService.RemoveResponsible")
+	defer span.End()
+
+	if memberId != userId {
+		if err := checkPermission(ctx, s.repo, userId, organizationId, entity.PermissionRoleManage); err != nil {
+			return err
+		}
+	}
+
+	return inTx(ctx, s.repo, "OrganizationService.RemoveResponsible", ErrCannotUpdateMember, func(repos *repository.Repository) error {
+		if err := checkNotLastOwner(ctx, repos, organizationId, memberId); err != nil {
+			return err
+		}
+
+		err := repos.Auth.RemoveResponsible(ctx, organizationId, memberId)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrMemberNotFound
+			}
+			logger.FromContext(ctx).Errorf("OrganizationService.RemoveResponsible: cannot remove responsible: %v", err)
+			return ErrCannotUpdateMember
+		}
+
+		return nil
+	})
+}
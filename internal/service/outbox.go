@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"tender-service/internal/entity"
+	"tender-service/internal/logger"
+	"tender-service/internal/outbox"
+	"tender-service/internal/repository"
+)
+
+type OutboxService struct {
+	repo      *repository.Repository
+	sinks     []outbox.Sink
+	batchSize int
+}
+
+func NewOutboxService(repo *repository.Repository, sinks []outbox.Sink, batchSize int) *OutboxService {
+	return &OutboxService{
+		repo:      repo,
+		sinks:     sinks,
+		batchSize: batchSize,
+	}
+}
+
+func (s *OutboxService) RelayEvents(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "OutboxService.RelayEvents")
+	defer span.End()
+
+	var relayed int
+	var sinkErr error
+	err := inTx(ctx, s.repo, "OutboxService.RelayEvents", ErrCannotRelayEvents, func(repos *repository.Repository) error {
+		locked, err := repos.Outbox.TryLockRelay(ctx)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("OutboxService.RelayEvents: cannot lock relay: %v", err)
+			return ErrCannotRelayEvents
+		}
+		if !locked {
+			return nil
+		}
+
+		events, err := repos.Outbox.GetUnpublishedEvents(ctx, s.batchSize)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("OutboxService.RelayEvents: cannot get events: %v", err)
+			return ErrCannotRelayEvents
+		}
+
+		published := make([]int64, 0, len(events))
+		for _, event := range events {
+			if sinkErr = s.dispatch(ctx, event); sinkErr != nil {
+				break
+			}
+			published = append(published, event.Sequence)
+		}
+		if len(published) == 0 {
+			return nil
+		}
+
+		if err := repos.Outbox.MarkPublished(ctx, published); err != nil {
+			logger.FromContext(ctx).Errorf("OutboxService.RelayEvents: cannot mark events published: %v", err)
+			return ErrCannotRelayEvents
+		}
+
+		relayed = len(published)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if sinkErr != nil {
+		logger.FromContext(ctx).Errorf("OutboxService.RelayEvents: %v", sinkErr)
+		return relayed, ErrCannotRelayEvents
+	}
+
+	return relayed, nil
+}
+
+func (s *OutboxService) dispatch(ctx context.Context, event entity.Event) error {
+	for _, sink := range s.sinks {
+		if err := sink.Handle(ctx, event); err != nil {
+			return fmt.Errorf("sink %s cannot handle event %s: %v", sink.Name(), event.Id, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"github.com/shopspring/decimal"
+	"tender-service/internal/entity"
+)
+
+var maxAmount = decimal.New(1, 16)
+
+func validateAmount(amount *decimal.Decimal, currency *string) error {
+	hasCurrency := currency != nil && *currency != ""
+	if (amount != nil) != hasCurrency {
+		return ErrCurrencyRequired
+	}
+	if amount == nil {
+		return nil
+	}
+	if amount.Sign() <= 0 || !amount.Equal(amount.Round(2)) || amount.GreaterThanOrEqual(maxAmount) {
+		return ErrInvalidAmount
+	}
+	return nil
+}
+
+func checkBidPrice(tender entity.Tender, price *decimal.Decimal, currency *string) error {
+	if err := validateAmount(price, currency); err != nil {
+		return err
+	}
+	if price == nil || tender.Budget == nil {
+		return nil
+	}
+	if tender.Currency == nil || *tender.Currency != *currency {
+		return ErrCurrencyMismatch
+	}
+	if price.GreaterThan(*tender.Budget) {
+		return ErrPriceExceedsBudget
+	}
+	return nil
+}
+
+// checkBidPrices checks the prices of a tender's existing bids against a budget or currency it is about to get.
+func checkBidPrices(tender entity.Tender, bids []entity.Bid) error {
+	for _, bid := range bids {
+		if err := checkBidPrice(tender, bid.Price, bid.Currency); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
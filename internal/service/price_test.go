@@ -0,0 +1,38 @@
+package service
+
+import (
+	"errors"
+	"github.com/shopspring/decimal"
+	"tender-service/internal/entity"
+	"testing"
+)
+
+func TestCheckBidPrices(t *testing.T) {
+	usd, eur := "USD", "EUR"
+	bids := []entity.Bid{
+		{Price: amount("300.00"), Currency: &usd},
+		{Price: amount("450.00"), Currency: &usd},
+		{},
+	}
+
+	tests := []struct {
+		name     string
+		budget   *decimal.Decimal
+		currency *string
+		wantErr  error
+	}{
+		{name: "budget covers bids", budget: amount("450.00"), currency: &usd},
+		{name: "budget below a bid", budget: amount("400.00"), currency: &usd, wantErr: ErrPriceExceedsBudget},
+		{name: "currency differs from bids", budget: amount("1000.00"), currency: &eur, wantErr: ErrCurrencyMismatch},
+		{name: "no budget", budget: nil, currency: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tender := entity.Tender{Budget: tt.budget, Currency: tt.currency}
+			if err := checkBidPrices(tender, bids); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkBidPrices() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"tender-service/internal/entity"
+	"tender-service/internal/logger"
+	"tender-service/internal/repository"
+	"tender-service/internal/repository/repoerrs"
+	"time"
+)
+
+type QuestionService struct {
+	repo *repository.Repository
+}
+
+func NewQuestionService(repo *repository.Repository) *QuestionService {
+	return &QuestionService{repo: repo}
+}
+
+func (s *QuestionService) GetQuestions(ctx context.Context, tenderId string, userId *string) ([]entity.Question, error) {
+	ctx, span := tracer.Start(ctx, "QuestionService.GetQuestions")
+	defer span.End()
+
+	tender, err := s.getTender(ctx, tenderId)
+	if err != nil {
+		return nil, err
+	}
+
+	responsible := userId != nil && checkPermission(ctx, s.repo, *userId, tender.OrganizationId, entity.PermissionTenderView) == nil
+	if tender.Status != "Published" && !responsible {
+		return nil, ErrNotEnoughPermissions
+	}
+
+	questions, err := s.repo.Question.GetQuestions(ctx, tenderId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("QuestionService.GetQuestions: cannot get questions: %v", err)
+		return nil, ErrCannotGetQuestion
+	}
+	if responsible {
+		return questions, nil
+	}
+
+	visible := make([]entity.Question, 0, len(questions))
+	for _, question := range questions {
+		if userId != nil && question.AuthorId == *userId {
+			visible = append(visible, question)
+			continue
+		}
+		if question.Visibility != nil && *question.Visibility == "Public" {
+			question.AuthorId = ""
+			question.AnsweredBy = nil
+			visible = append(visible, question)
+		}
+	}
+
+	return visible, nil
+}
+func (s *QuestionService) AskQuestion(ctx context.Context, tenderId string, userId string, input entity.AskQuestionInput) (entity.Question, error) {
+	ctx, span := tracer.Start(ctx, "QuestionService.AskQuestion")
+	defer span.End()
+
+	tender, err := s.getTender(ctx, tenderId)
+	if err != nil {
+		return entity.Question{}, err
+	}
+	if tender.Status != "Published" {
+		return entity.Question{}, ErrTenderNotPublished
+	}
+	if questionDeadlinePassed(tender) {
+		return entity.Question{}, ErrQuestionDeadlinePassed
+	}
+	if checkPermission(ctx, s.repo, userId, tender.OrganizationId, entity.PermissionTenderView) == nil {
+		return entity.Question{}, ErrOwnTenderQuestion
+	}
+
+	question, err := s.repo.Question.CreateQuestion(ctx, entity.Question{
+		TenderId: tenderId,
+		AuthorId: userId,
+		Text:     input.Text,
+	})
+	if err != nil {
+		logger.FromContext(ctx).Errorf("QuestionService.AskQuestion: cannot create question: %v", err)
+		return entity.Question{}, ErrCannotCreateQuestion
+	}
+
+	return question, nil
+}
+func (s *QuestionService) AnswerQuestion(ctx context.Context, tenderId string, questionId string, userId string, input entity.AnswerQuestionInput) (entity.Question, error) {
+	ctx, span := tracer.Start(ctx, "QuestionService.AnswerQuestion")
+	defer span.End()
+
+	tender, err := s.getTender(ctx, tenderId)
+	if err != nil {
+		return entity.Question{}, err
+	}
+	if err := checkPermission(ctx, s.repo, userId, tender.OrganizationId, entity.PermissionTenderEdit); err != nil {
+		return entity.Question{}, err
+	}
+
+	question, err := s.repo.Question.GetQuestionById(ctx, questionId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.Question{}, ErrQuestionNotFound
+		}
+		logger.FromContext(ctx).Errorf("QuestionService.AnswerQuestion: cannot get question: %v", err)
+		return entity.Question{}, ErrCannotGetQuestion
+	}
+	if question.TenderId != tenderId {
+		return entity.Question{}, ErrQuestionNotFound
+	}
+
+	question, err = s.repo.Question.AnswerQuestion(ctx, questionId, input.Answer, input.Visibility, userId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.Question{}, ErrQuestionNotFound
+		}
+		logger.FromContext(ctx).Errorf("QuestionService.AnswerQuestion: cannot answer question: %v", err)
+		return entity.Question{}, ErrCannotAnswerQuestion
+	}
+
+	return question, nil
+}
+
+func (s *QuestionService) getTender(ctx context.Context, tenderId string) (entity.Tender, error) {
+	tender, err := s.repo.Tender.GetTenderById(ctx, tenderId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.Tender{}, ErrTenderNotFound
+		}
+		logger.FromContext(ctx).Errorf("QuestionService.getTender: cannot get tender: %v", err)
+		return entity.Tender{}, ErrCannotGetTender
+	}
+
+	return tender, nil
+}
+
+func questionDeadlinePassed(tender entity.Tender) bool {
+	if tender.QuestionDeadline != nil {
+		return !time.Now().Before(*tender.QuestionDeadline)
+	}
+	return deadlinePassed(tender)
+}
+
+func checkQuestionDeadline(questionDeadline *time.Time, submissionDeadline *time.Time) error {
+	if questionDeadline == nil {
+		return nil
+	}
+	if submissionDeadline != nil && questionDeadline.After(*submissionDeadline) {
+		return ErrInvalidQuestionDeadline
+	}
+	return nil
+}
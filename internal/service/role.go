@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"tender-service/internal/entity"
+	"tender-service/internal/logger"
+	"tender-service/internal/repository"
+	"tender-service/internal/repository/repoerrs"
+)
+
+var rolePermissions = map[string][]string{
+	entity.RoleOwner: {
+		entity.PermissionTenderCreate,
+		entity.PermissionTenderView,
+		entity.PermissionTenderEdit,
+		entity.PermissionTenderRollback,
+		entity.PermissionTenderStatus,
+		entity.PermissionBidDecision,
+		entity.PermissionBidScore,
+		entity.PermissionRoleManage,
+		entity.PermissionOrganizationManage,
+	},
+	entity.RoleEditor: {
+		entity.PermissionTenderCreate,
+		entity.PermissionTenderView,
+		entity.PermissionTenderEdit,
+		entity.PermissionTenderRollback,
+		entity.PermissionTenderStatus,
+	},
+	entity.RoleApprover: {
+		entity.PermissionTenderView,
+		entity.PermissionBidDecision,
+		entity.PermissionBidScore,
+	},
+	entity.RoleViewer: {
+		entity.PermissionTenderView,
+	},
+}
+
+type RoleService struct {
+	repo *repository.Repository
+}
+
+func NewRoleService(repo *repository.Repository) *RoleService {
+	return &RoleService{repo: repo}
+}
+
+func (s *RoleService) GetOrganizationRoles(ctx context.Context, organizationId string, userId string) ([]entity.OrganizationMember, error) {
+	ctx, span := tracer.Start(ctx, "RoleService.GetOrganizationRoles")
+	defer span.End()
+
+	if err := checkPermission(ctx, s.repo, userId, organizationId, entity.PermissionTenderView); err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.Auth.GetOrganizationMembers(ctx, organizationId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("RoleService.GetOrganizationRoles: cannot get organization members: %v", err)
+		return nil, ErrCannotGetMembers
+	}
+
+	return members, nil
+}
+func (s *RoleService) GrantRole(ctx context.Context, organizationId string, memberId string, role string, userId string) (entity.OrganizationMember, error) {
+	ctx, span := tracer.Start(ctx, "RoleService.GrantRole")
+	defer span.End()
+
+	if err := checkPermission(ctx, s.repo, userId, organizationId, entity.PermissionRoleManage); err != nil {
+		return entity.OrganizationMember{}, err
+	}
+
+	var member entity.OrganizationMember
+	err := inTx(ctx, s.repo, "RoleService.GrantRole", ErrCannotUpdateMember, func(repos *repository.Repository) error {
+		if role != entity.RoleOwner {
+			if err := checkNotLastOwner(ctx, repos, organizationId, memberId); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		member, err = repos.Auth.UpdateMemberRole(ctx, organizationId, memberId, role)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrMemberNotFound
+			}
+			logger.FromContext(ctx).Errorf("RoleService.GrantRole: cannot update member role: %v", err)
+			return ErrCannotUpdateMember
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.OrganizationMember{}, err
+	}
+
+	return member, nil
+}
+func (s *RoleService) RevokeRole(ctx context.Context, organizationId string, memberId string, userId string) (entity.OrganizationMember, error) {
+	ctx, span := tracer.Start(ctx, "RoleService.RevokeRole")
+	defer span.End()
+
+	return s.GrantRole(ctx, organizationId, memberId, entity.RoleViewer, userId)
+}
+
+// checkNotLastOwner must run inside a transaction: it locks the organization's responsibles, so the result stays
+// valid until the caller's role change or removal commits.
+func checkNotLastOwner(ctx context.Context, repo *repository.Repository, organizationId string, memberId string) error {
+	if err := repo.Auth.LockOrganizationMembers(ctx, organizationId); err != nil {
+		logger.FromContext(ctx).Errorf("service.checkNotLastOwner: cannot lock organization members: %v", err)
+		return ErrCannotGetMembers
+	}
+
+	members, err := repo.Auth.GetOrganizationMembers(ctx, organizationId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("service.checkNotLastOwner: cannot get organization members: %v", err)
+		return ErrCannotGetMembers
+	}
+
+	owners := 0
+	isOwner := false
+	for _, member := range members {
+		if member.Role == entity.RoleOwner {
+			owners++
+			if member.UserId == memberId {
+				isOwner = true
+			}
+		}
+	}
+	if isOwner && owners == 1 {
+		return ErrLastOwner
+	}
+
+	return nil
+}
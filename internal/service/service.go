@@ -2,46 +2,216 @@ package service
 
 import (
 	"context"
+	"github.com/sirupsen/logrus"
 	"tender-service/internal/entity"
+	"tender-service/internal/metrics"
+	"tender-service/internal/outbox"
 	"tender-service/internal/repository"
+	"tender-service/internal/storage"
+	"tender-service/internal/webhook"
+	"time"
 )
 
 type Auth interface {
+	GenerateToken(ctx context.Context, input entity.SignInInput) (string, error)
+	ParseToken(ctx context.Context, accessToken string) (string, error)
 	GetUserId(ctx context.Context, username string) (string, error)
 	CheckResponsibility(ctx context.Context, userId string, organizationId string) (bool, error)
 }
 
+type Employee interface {
+	CreateEmployee(ctx context.Context, input entity.CreateEmployeeInput, userId *string) (entity.Employee, error)
+	GetEmployees(ctx context.Context, limit int, offset int) ([]entity.Employee, error)
+	GetEmployeeById(ctx context.Context, id string) (entity.Employee, error)
+	EditEmployee(ctx context.Context, id string, userId string, input entity.EditEmployeeInput) (entity.Employee, error)
+	DeleteEmployee(ctx context.Context, id string, userId string) error
+	IssuePasswordReset(ctx context.Context, id string, userId string) (entity.PasswordReset, error)
+	ResetPassword(ctx context.Context, input entity.ResetPasswordInput) error
+}
+
+type Organization interface {
+	CreateOrganization(ctx context.Context, userId string, input entity.CreateOrganizationInput) (entity.Organization, error)
+	GetOrganizations(ctx context.Context, limit int, offset int) ([]entity.Organization, error)
+	GetOrganizationById(ctx context.Context, id string) (entity.Organization, error)
+	EditOrganization(ctx context.Context, id string, userId string, input entity.EditOrganizationInput) (entity.Organization, error)
+	DeleteOrganization(ctx context.Context, id string, userId string) error
+	AddResponsible(ctx context.Context, organizationId string, userId string, input entity.AddResponsibleInput) ([]entity.OrganizationMember, error)
+	RemoveResponsible(ctx context.Context, organizationId string, memberId string, userId string) error
+}
+
+type Role interface {
+	GetOrganizationRoles(ctx context.Context, organizationId string, userId string) ([]entity.OrganizationMember, error)
+	GrantRole(ctx context.Context, organizationId string, memberId string, role string, userId string) (entity.OrganizationMember, error)
+	RevokeRole(ctx context.Context, organizationId string, memberId string, userId string) (entity.OrganizationMember, error)
+}
+
 type Tender interface {
-	GetTenders(ctx context.Context, limit int, offset int, serviceType []string) ([]entity.Tender, error)
+	GetTenders(ctx context.Context, page entity.PageRequest, filter entity.TenderFilter) (entity.Page[entity.Tender], error)
 	CreateTender(ctx context.Context, userId string, input entity.CreateTenderInput) (entity.Tender, error)
-	GetTendersByUserId(ctx context.Context, limit int, offset int, userId string) ([]entity.Tender, error)
+	GetTendersByUserId(ctx context.Context, page entity.PageRequest, userId string) (entity.Page[entity.Tender], error)
 	GetTenderStatus(ctx context.Context, tenderId string, userId *string) (string, error)
 	UpdateTenderStatus(ctx context.Context, tenderId, status, userId string) (entity.Tender, error)
 	EditTender(ctx context.Context, tenderId string, userId string, input entity.EditTenderInput) (entity.Tender, error)
 	RollbackTender(ctx context.Context, tenderId string, version int, userId string) (entity.Tender, error)
+	GetTenderVersions(ctx context.Context, tenderId string, userId string) ([]entity.Tender, error)
+	GetTenderVersion(ctx context.Context, tenderId string, version int, userId string) (entity.Tender, error)
+	DiffTenderVersions(ctx context.Context, tenderId string, from int, to int, userId string) (entity.VersionDiff, error)
+	CloseExpiredTenders(ctx context.Context) (int, error)
+	OpenBids(ctx context.Context, tenderId string, userId string) (entity.Tender, error)
 }
 
 type Bid interface {
 	CreateBid(ctx context.Context, input entity.CreateBidInput) (entity.Bid, error)
-	GetBidsByUserId(ctx context.Context, limit int, offset int, userId string) ([]entity.Bid, error)
-	GetBidsForTender(ctx context.Context, tenderId string, userId string, limit int, offset int) ([]entity.Bid, error)
+	GetBidsByUserId(ctx context.Context, page entity.PageRequest, userId string) (entity.Page[entity.Bid], error)
+	GetBidsForTender(ctx context.Context, tenderId string, userId string, page entity.PageRequest, filter entity.BidFilter) (entity.TenderBids, error)
 	GetBidStatus(ctx context.Context, bidId string, userId string) (string, error)
 	UpdateBidStatus(ctx context.Context, bidId, status, userId string) (entity.Bid, error)
 	EditBid(ctx context.Context, bidId string, userId string, input entity.EditBidInput) (entity.Bid, error)
-	SubmitBidDecision(ctx context.Context, bidId, decision, userId string) (entity.Bid, error)
+	SubmitBidDecision(ctx context.Context, bidId, lotId, decision, userId string) (entity.BidWithDecisions, error)
+	GetBidDecisions(ctx context.Context, bidId string, userId string) (entity.BidWithDecisions, error)
 	RollbackBid(ctx context.Context, bidId string, version int, userId string) (entity.Bid, error)
+	GetBidVersions(ctx context.Context, bidId string, userId string) ([]entity.Bid, error)
+	GetBidVersion(ctx context.Context, bidId string, version int, userId string) (entity.Bid, error)
+	DiffBidVersions(ctx context.Context, bidId string, from int, to int, userId string) (entity.VersionDiff, error)
+}
+
+type Lot interface {
+	GetLots(ctx context.Context, tenderId string, userId *string) ([]entity.Lot, error)
+	AddLots(ctx context.Context, tenderId string, userId string, input entity.AddLotsInput) ([]entity.Lot, error)
+	CancelLot(ctx context.Context, tenderId string, lotId string, userId string) (entity.Lot, error)
+}
+
+type Question interface {
+	GetQuestions(ctx context.Context, tenderId string, userId *string) ([]entity.Question, error)
+	AskQuestion(ctx context.Context, tenderId string, userId string, input entity.AskQuestionInput) (entity.Question, error)
+	AnswerQuestion(ctx context.Context, tenderId string, questionId string, userId string, input entity.AnswerQuestionInput) (entity.Question, error)
+}
+
+type Evaluation interface {
+	GetCriteria(ctx context.Context, tenderId string, userId string) ([]entity.Criterion, error)
+	SetCriteria(ctx context.Context, tenderId string, userId string, input entity.SetCriteriaInput) ([]entity.Criterion, error)
+	SubmitScores(ctx context.Context, bidId string, userId string, input entity.SubmitScoresInput) ([]entity.BidScore, error)
+	GetBidScores(ctx context.Context, bidId string, userId string) ([]entity.BidScore, error)
+	GetBidScoreHistory(ctx context.Context, bidId string, userId string) ([]entity.BidScore, error)
+	GetTenderEvaluation(ctx context.Context, tenderId string, userId string) (entity.TenderEvaluation, error)
+}
+
+type Attachment interface {
+	GetAttachments(ctx context.Context, owner entity.AttachmentOwner, userId string, version *int) ([]entity.Attachment, error)
+	UploadAttachment(ctx context.Context, owner entity.AttachmentOwner, userId string, expectedVersion *int, upload entity.AttachmentUpload) (entity.Attachment, error)
+	DownloadAttachment(ctx context.Context, owner entity.AttachmentOwner, attachmentId string, userId string) (entity.AttachmentContent, error)
+	DeleteAttachment(ctx context.Context, owner entity.AttachmentOwner, attachmentId string, userId string, expectedVersion *int) error
+}
+
+type Webhook interface {
+	CreateWebhook(ctx context.Context, organizationId string, userId string, input entity.CreateWebhookInput) (entity.CreatedWebhook, error)
+	GetWebhooks(ctx context.Context, organizationId string, userId string) ([]entity.Webhook, error)
+	DeleteWebhook(ctx context.Context, organizationId string, webhookId string, userId string) error
+	GetDeliveries(ctx context.Context, organizationId string, webhookId string, userId string, limit int, offset int) ([]entity.WebhookDelivery, error)
+	DeliverPending(ctx context.Context) (int, error)
+}
+
+type Event interface {
+	Subscribe(ctx context.Context, userId string, filter entity.EventFilter) (<-chan entity.Event, func(), error)
+	Listen(ctx context.Context) error
+	Close()
+}
+
+type Health interface {
+	Readiness(ctx context.Context) (entity.Readiness, error)
+	Drain()
+}
+
+type Outbox interface {
+	RelayEvents(ctx context.Context) (int, error)
 }
 
 type Service struct {
-	Auth   Auth
-	Tender Tender
-	Bid    Bid
+	Auth         Auth
+	Employee     Employee
+	Organization Organization
+	Role         Role
+	Tender       Tender
+	Bid          Bid
+	Lot          Lot
+	Question     Question
+	Evaluation   Evaluation
+	Attachment   Attachment
+	Webhook      Webhook
+	Outbox       Outbox
+	Event        Event
+	Health       Health
+}
+
+type ServicesDependencies struct {
+	Repos          *repository.Repository
+	Metrics        *metrics.Metrics
+	SignKey        string
+	TokenTTL       time.Duration
+	ResetTokenTTL  time.Duration
+	SelfSignUp     bool
+	ApprovalQuorum int
+
+	BlobStore              storage.BlobStore
+	MaxAttachmentSize      int64
+	AllowedAttachmentTypes []string
+
+	WebhookSender      webhook.Sender
+	WebhookTimeout     time.Duration
+	WebhookMaxAttempts int
+	WebhookBaseBackoff time.Duration
+	WebhookMaxBackoff  time.Duration
+	WebhookBatchSize   int
+
+	EventSinks       []string
+	EventSubscribers *outbox.Subscribers
+	OutboxBatchSize  int
+	EventBufferSize  int
+	EventMembersTTL  time.Duration
+
+	SchemaVersion int
 }
 
-func NewService(repos *repository.Repository) *Service {
+func NewService(deps ServicesDependencies) *Service {
+	webhooks := NewWebhookService(deps.Repos, deps.WebhookSender, deps.WebhookTimeout, deps.WebhookMaxAttempts,
+		deps.WebhookBaseBackoff, deps.WebhookMaxBackoff, deps.WebhookBatchSize)
+	events := NewEventService(deps.Repos, deps.EventBufferSize, deps.EventMembersTTL)
+
 	return &Service{
-		Auth:   NewAuthService(repos),
-		Tender: NewTenderService(repos),
-		Bid:    NewBidService(repos),
+		Auth:         NewAuthService(deps.Repos, deps.SignKey, deps.TokenTTL),
+		Employee:     NewEmployeeService(deps.Repos, deps.ResetTokenTTL, deps.SelfSignUp),
+		Organization: NewOrganizationService(deps.Repos),
+		Role:         NewRoleService(deps.Repos),
+		Tender:       NewTenderService(deps.Repos, deps.Metrics),
+		Bid:          NewBidService(deps.Repos, deps.Metrics, deps.ApprovalQuorum),
+		Lot:          NewLotService(deps.Repos, deps.Metrics),
+		Question:     NewQuestionService(deps.Repos),
+		Evaluation:   NewEvaluationService(deps.Repos),
+		Attachment:   NewAttachmentService(deps.Repos, deps.BlobStore, deps.MaxAttachmentSize, deps.AllowedAttachmentTypes),
+		Webhook:      webhooks,
+		Outbox:       NewOutboxService(deps.Repos, eventSinks(deps, webhooks, events), deps.OutboxBatchSize),
+		Event:        events,
+		Health:       NewHealthService(deps.Repos, deps.SchemaVersion),
+	}
+}
+
+func eventSinks(deps ServicesDependencies, webhooks *WebhookService, events *EventService) []outbox.Sink {
+	sinks := make([]outbox.Sink, 0, len(deps.EventSinks))
+	for _, name := range deps.EventSinks {
+		switch name {
+		case "log":
+			sinks = append(sinks, outbox.NewLogSink())
+		case "webhook":
+			sinks = append(sinks, webhooks)
+		case "notify":
+			sinks = append(sinks, events)
+		case "subscribers":
+			if deps.EventSubscribers != nil {
+				sinks = append(sinks, deps.EventSubscribers)
+			}
+		default:
+			logrus.Warnf("service.eventSinks: unknown event sink %q is ignored", name)
+		}
 	}
+	return sinks
 }
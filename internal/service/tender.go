@@ -3,178 +3,575 @@ package service
 import (
 	"context"
 	"errors"
-	"fmt"
-	"github.com/sirupsen/logrus"
 	"tender-service/internal/entity"
+	"tender-service/internal/logger"
+	"tender-service/internal/metrics"
 	"tender-service/internal/repository"
 	"tender-service/internal/repository/repoerrs"
+	"time"
 )
 
 type TenderService struct {
-	repo *repository.Repository
+	repo    *repository.Repository
+	metrics *metrics.Metrics
 }
 
-func NewTenderService(repo *repository.Repository) *TenderService {
-	return &TenderService{repo: repo}
+func NewTenderService(repo *repository.Repository, metrics *metrics.Metrics) *TenderService {
+	return &TenderService{
+		repo:    repo,
+		metrics: metrics,
+	}
 }
 
-func (s *TenderService) GetTenders(ctx context.Context, limit int, offset int, serviceType []string) ([]entity.Tender, error) {
-	return s.repo.Tender.GetTenders(ctx, limit, offset, serviceType)
+func (s *TenderService) GetTenders(ctx context.Context, page entity.PageRequest, filter entity.TenderFilter) (entity.Page[entity.Tender], error) {
+	ctx, span := tracer.Start(ctx, "TenderService.GetTenders")
+	defer span.End()
+
+	tenders, err := s.repo.Tender.GetTenders(ctx, page, filter)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrInvalidCursor) {
+			return entity.Page[entity.Tender]{}, ErrInvalidCursor
+		}
+		logger.FromContext(ctx).Errorf("TenderService.GetTenders: cannot get tenders: %v", err)
+		return entity.Page[entity.Tender]{}, ErrCannotGetTender
+	}
+
+	return tenders, nil
 }
 func (s *TenderService) CreateTender(ctx context.Context, userId string, input entity.CreateTenderInput) (entity.Tender, error) {
+	ctx, span := tracer.Start(ctx, "TenderService.CreateTender")
+	defer span.End()
+
+	if err := checkPermission(ctx, s.repo, userId, input.OrganizationId, entity.PermissionTenderCreate); err != nil {
+		return entity.Tender{}, err
+	}
+	if input.SubmissionDeadline != nil && !input.SubmissionDeadline.After(time.Now()) {
+		return entity.Tender{}, ErrInvalidDeadline
+	}
+	if input.QuestionDeadline != nil && !input.QuestionDeadline.After(time.Now()) {
+		return entity.Tender{}, ErrInvalidDeadline
+	}
+	if err := checkQuestionDeadline(input.QuestionDeadline, input.SubmissionDeadline); err != nil {
+		return entity.Tender{}, err
+	}
+	if err := validateAmount(input.Budget, &input.Currency); err != nil {
+		return entity.Tender{}, err
+	}
+
 	tender := entity.Tender{
-		Name:           input.Name,
-		Description:    input.Description,
-		ServiceType:    input.ServiceType,
-		Status:         "Created",
-		OrganizationId: input.OrganizationId,
-		Version:        1,
+		Name:               input.Name,
+		Description:        input.Description,
+		ServiceType:        input.ServiceType,
+		Status:             "Created",
+		OrganizationId:     input.OrganizationId,
+		Version:            1,
+		SubmissionDeadline: input.SubmissionDeadline,
+		QuestionDeadline:   input.QuestionDeadline,
+		Sealed:             input.Sealed,
+		Budget:             input.Budget,
+		Currency:           optionalString(input.Currency),
 	}
-	return s.repo.Tender.CreateTender(ctx, userId, tender)
+
+	lotInputs := input.Lots
+	if len(lotInputs) == 0 {
+		lotInputs = []entity.LotInput{{
+			Name:        input.Name,
+			Description: input.Description,
+			ServiceType: input.ServiceType,
+			Budget:      input.Budget,
+		}}
+	}
+	lots := newLots(lotInputs, 1)
+	if err := checkLotBudgets(tender, lots); err != nil {
+		return entity.Tender{}, err
+	}
+
+	err := inTx(ctx, s.repo, "TenderService.CreateTender", ErrCannotCreateTender, func(repos *repository.Repository) error {
+		var err error
+		tender, err = repos.Tender.CreateTender(ctx, userId, tender)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("TenderService.CreateTender: cannot create tender: %v", err)
+			return ErrCannotCreateTender
+		}
+
+		_, err = repos.Lot.CreateLots(ctx, tender.Id, lots)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("TenderService.CreateTender: cannot create lots: %v", err)
+			return ErrCannotCreateTender
+		}
+		return nil
+	})
+	if err != nil {
+		return entity.Tender{}, err
+	}
+	s.metrics.TenderCreated()
+
+	return tender, nil
 }
-func (s *TenderService) GetTendersByUserId(ctx context.Context, limit int, offset int, userId string) ([]entity.Tender, error) {
-	return s.repo.Tender.GetTendersByUserId(ctx, limit, offset, userId)
+func (s *TenderService) GetTendersByUserId(ctx context.Context, page entity.PageRequest, userId string) (entity.Page[entity.Tender], error) {
+	ctx, span := tracer.Start(ctx, "TenderService.GetTendersByUserId")
+	defer span.End()
+
+	tenders, err := s.repo.Tender.GetTendersByUserId(ctx, page, userId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrInvalidCursor) {
+			return entity.Page[entity.Tender]{}, ErrInvalidCursor
+		}
+		logger.FromContext(ctx).Errorf("TenderService.GetTendersByUserId: cannot get tenders: %v", err)
+		return entity.Page[entity.Tender]{}, ErrCannotGetTender
+	}
+
+	return tenders, nil
 }
 func (s *TenderService) GetTenderStatus(ctx context.Context, tenderId string, userId *string) (string, error) {
+	ctx, span := tracer.Start(ctx, "TenderService.GetTenderStatus")
+	defer span.End()
+
 	tender, err := s.repo.Tender.GetTenderById(ctx, tenderId)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
 			return "", ErrTenderNotFound
 		}
-		logrus.Errorf("TenderService.GetTenderStatus: cannot get tender: %v", err)
+		logger.FromContext(ctx).Errorf("TenderService.GetTenderStatus: cannot get tender: %v", err)
 		return "", ErrCannotGetTender
 	}
 	if tender.Status != "Published" {
 		if userId == nil {
 			return "", ErrNotEnoughPermissions
 		}
-		userOrganizationId, err := s.repo.Auth.GetUserOrganizationId(ctx, *userId)
+		if err := checkPermission(ctx, s.repo, *userId, tender.OrganizationId, entity.PermissionTenderView); err != nil {
+			return "", err
+		}
+	}
+	return tender.Status, nil
+}
+func (s *TenderService) UpdateTenderStatus(ctx context.Context, tenderId, status, userId string) (entity.Tender, error) {
+	ctx, span := tracer.Start(ctx, "TenderService.UpdateTenderStatus")
+	defer span.End()
+
+	var result entity.Tender
+	err := inTx(ctx, s.repo, "TenderService.UpdateTenderStatus", ErrCannotUpdateTender, func(repos *repository.Repository) error {
+		if err := repos.Tender.LockTender(ctx, tenderId); err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrTenderNotFound
+			}
+			logger.FromContext(ctx).Errorf("TenderService.UpdateTenderStatus: cannot lock tender: %v", err)
+			return ErrCannotUpdateTender
+		}
+
+		tender, err := repos.Tender.GetTenderById(ctx, tenderId)
 		if err != nil {
 			if errors.Is(err, repoerrs.ErrNotFound) {
-				return "", ErrNotEnoughPermissions
+				return ErrTenderNotFound
 			}
-			logrus.Errorf("TenderService.GetTenderStatus: cannot get user organization id: %v", err)
-			return "", ErrNotEnoughPermissions
+			logger.FromContext(ctx).Errorf("TenderService.UpdateTenderStatus: cannot get tender: %v", err)
+			return ErrCannotGetTender
 		}
-		if userOrganizationId != tender.OrganizationId {
-			return "", ErrNotEnoughPermissions
+
+		if tender.Status == "Closed" {
+			return tenderClosedError(tender.Id)
+		} else if tender.Status == status {
+			return statusUnchangedError(status)
+		} else if tender.Status == "Published" && status == "Created" {
+			return statusRevertedError(tender.Status, status)
+		}
+
+		if err := checkPermission(ctx, repos, userId, tender.OrganizationId, entity.PermissionTenderStatus); err != nil {
+			return err
+		}
+
+		if status == "Closed" {
+			if _, err := repos.Lot.CancelOpenLots(ctx, tenderId); err != nil {
+				logger.FromContext(ctx).Errorf("TenderService.UpdateTenderStatus: cannot cancel open lots: %v", err)
+				return ErrCannotUpdateLot
+			}
 		}
+
+		tender, err = repos.Tender.UpdateTenderStatus(ctx, tenderId, status)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrTenderNotFound
+			}
+			logger.FromContext(ctx).Errorf("TenderService.UpdateTenderStatus: cannot update tender: %v", err)
+			return ErrCannotUpdateTender
+		}
+
+		result = tender
+		return nil
+	})
+	if err != nil {
+		return entity.Tender{}, err
 	}
-	return tender.Status, nil
+
+	switch result.Status {
+	case "Published":
+		s.metrics.TenderPublished()
+	case "Closed":
+		s.metrics.TendersClosed(1)
+	}
+
+	return result, nil
 }
-func (s *TenderService) UpdateTenderStatus(ctx context.Context, tenderId, status, userId string) (entity.Tender, error) {
-	tender, err := s.repo.Tender.GetTenderById(ctx, tenderId)
+func (s *TenderService) EditTender(ctx context.Context, tenderId string, userId string, input entity.EditTenderInput) (entity.Tender, error) {
+	ctx, span := tracer.Start(ctx, "TenderService.EditTender")
+	defer span.End()
+
+	var result entity.Tender
+	err := inTx(ctx, s.repo, "TenderService.EditTender", ErrCannotUpdateTender, func(repos *repository.Repository) error {
+		tender, err := lockTender(ctx, repos, tenderId)
+		if err != nil {
+			return err
+		}
+		if tender.Status == "Closed" {
+			return tenderClosedError(tender.Id)
+		}
+
+		if err := checkPermission(ctx, repos, userId, tender.OrganizationId, entity.PermissionTenderEdit); err != nil {
+			return err
+		}
+		if input.SubmissionDeadline != nil && !input.SubmissionDeadline.After(time.Now()) {
+			return ErrInvalidDeadline
+		}
+		if input.QuestionDeadline != nil && !input.QuestionDeadline.After(time.Now()) {
+			return ErrInvalidDeadline
+		}
+		if input.SubmissionDeadline != nil || input.QuestionDeadline != nil {
+			questionDeadline, submissionDeadline := tender.QuestionDeadline, tender.SubmissionDeadline
+			if input.QuestionDeadline != nil {
+				questionDeadline = input.QuestionDeadline
+			}
+			if input.SubmissionDeadline != nil {
+				submissionDeadline = input.SubmissionDeadline
+			}
+			if err := checkQuestionDeadline(questionDeadline, submissionDeadline); err != nil {
+				return err
+			}
+		}
+		if input.Budget != nil || input.Currency != nil {
+			edited := tender
+			if input.Budget != nil {
+				edited.Budget = input.Budget
+			}
+			if input.Currency != nil {
+				edited.Currency = input.Currency
+			}
+			if err := validateAmount(edited.Budget, edited.Currency); err != nil {
+				return err
+			}
+
+			lots, err := repos.Lot.GetLots(ctx, tenderId)
+			if err != nil {
+				logger.FromContext(ctx).Errorf("TenderService.EditTender: cannot get lots: %v", err)
+				return ErrCannotGetLot
+			}
+			if err := checkLotBudgets(edited, lots); err != nil {
+				return err
+			}
+
+			bids, err := repos.Bid.GetActiveBidsForTender(ctx, tenderId)
+			if err != nil {
+				logger.FromContext(ctx).Errorf("TenderService.EditTender: cannot get bids: %v", err)
+				return ErrCannotGetBid
+			}
+			if err := checkBidPrices(edited, bids); err != nil {
+				return err
+			}
+		}
+		if input.Version != nil && *input.Version != tender.Version {
+			return &VersionConflictError{CurrentVersion: tender.Version}
+		}
+
+		tender, err = repos.Tender.UpdateTender(ctx, tenderId, input)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrTenderNotFound
+			}
+			if errors.Is(err, repoerrs.ErrConflict) {
+				return s.versionConflict(ctx, repos, tenderId)
+			}
+			logger.FromContext(ctx).Errorf("TenderService.EditTender: cannot update tender: %v", err)
+			return ErrCannotUpdateTender
+		}
+
+		result = tender
+		return nil
+	})
 	if err != nil {
-		if errors.Is(err, repoerrs.ErrNotFound) {
-			return entity.Tender{}, ErrTenderNotFound
+		return entity.Tender{}, err
+	}
+
+	return result, nil
+}
+func (s *TenderService) RollbackTender(ctx context.Context, tenderId string, version int, userId string) (entity.Tender, error) {
+	ctx, span := tracer.Start(ctx, "TenderService.RollbackTender")
+	defer span.End()
+
+	var result entity.Tender
+	err := inTx(ctx, s.repo, "TenderService.RollbackTender", ErrCannotUpdateTender, func(repos *repository.Repository) error {
+		if err := repos.Tender.LockTender(ctx, tenderId); err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrTenderNotFound
+			}
+			logger.FromContext(ctx).Errorf("TenderService.RollbackTender: cannot lock tender: %v", err)
+			return ErrCannotUpdateTender
 		}
-		logrus.Errorf("TenderService.UpdateTenderStatus: cannot get tender: %v", err)
-		return entity.Tender{}, ErrCannotGetTender
+
+		tender, err := repos.Tender.GetTenderById(ctx, tenderId)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrTenderNotFound
+			}
+			logger.FromContext(ctx).Errorf("TenderService.RollbackTender: cannot get tender: %v", err)
+			return ErrCannotGetTender
+		}
+		if tender.Version <= version {
+			return invalidRollbackError(tender.Version, version)
+		}
+		if tender.Status == "Closed" {
+			return tenderClosedError(tender.Id)
+		}
+
+		if err := checkPermission(ctx, repos, userId, tender.OrganizationId, entity.PermissionTenderRollback); err != nil {
+			return err
+		}
+
+		target, err := repos.Tender.GetTenderVersion(ctx, tenderId, version)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrVersionNotFound
+			}
+			logger.FromContext(ctx).Errorf("TenderService.RollbackTender: cannot get tender version: %v", err)
+			return ErrCannotGetTender
+		}
+		if err := checkRestoredDeadlines(tender, target); err != nil {
+			return err
+		}
+		if err := s.checkRollbackAmounts(ctx, repos, tender, target); err != nil {
+			return err
+		}
+
+		tender, err = repos.Tender.RollbackTender(ctx, tenderId, version)
+		if err != nil {
+			if errors.Is(err, repoerrs.ErrNotFound) {
+				return ErrTenderNotFound
+			}
+			logger.FromContext(ctx).Errorf("TenderService.RollbackTender: cannot update tender: %v", err)
+			return ErrCannotUpdateTender
+		}
+
+		owner := entity.AttachmentOwner{Type: entity.AttachmentOwnerTender, Id: tenderId}
+		if err := repos.Attachment.RestoreAttachments(ctx, owner, version, tender.Version); err != nil {
+			logger.FromContext(ctx).Errorf("TenderService.RollbackTender: cannot restore attachments: %v", err)
+			return ErrCannotUpdateTender
+		}
+
+		result = tender
+		return nil
+	})
+	if err != nil {
+		return entity.Tender{}, err
 	}
 
-	if tender.Status == "Closed" {
-		return entity.Tender{}, fmt.Errorf("cannot edit closed tender")
-	} else if tender.Status == status {
-		return entity.Tender{}, fmt.Errorf("impossible change the status to the same")
-	} else if tender.Status == "Published" && status == "Created" {
-		return entity.Tender{}, fmt.Errorf("impossible change the status to the previous one")
+	return result, nil
+}
+
+// checkRollbackAmounts checks that the budget and currency of the target version still fit the tender's lots and
+// the bids already submitted to it.
+func (s *TenderService) checkRollbackAmounts(ctx context.Context, repos *repository.Repository, current entity.Tender, target entity.Tender) error {
+	restored := current
+	restored.Budget = target.Budget
+	restored.Currency = target.Currency
+	if err := validateAmount(restored.Budget, restored.Currency); err != nil {
+		return err
 	}
 
-	userOrganizationId, err := s.repo.Auth.GetUserOrganizationId(ctx, userId)
+	lots, err := repos.Lot.GetLots(ctx, current.Id)
 	if err != nil {
-		if errors.Is(err, repoerrs.ErrNotFound) {
-			return entity.Tender{}, ErrNotEnoughPermissions
-		}
-		logrus.Errorf("TenderService.UpdateTenderStatus: cannot get user organization id: %v", err)
-		return entity.Tender{}, ErrNotEnoughPermissions
+		logger.FromContext(ctx).Errorf("TenderService.checkRollbackAmounts: cannot get lots: %v", err)
+		return ErrCannotGetLot
 	}
-	if userOrganizationId != tender.OrganizationId {
-		return entity.Tender{}, ErrNotEnoughPermissions
+	if err := checkLotBudgets(restored, lots); err != nil {
+		return err
 	}
 
-	tender, err = s.repo.Tender.UpdateTenderStatus(ctx, tenderId, status)
+	bids, err := repos.Bid.GetActiveBidsForTender(ctx, current.Id)
 	if err != nil {
-		if errors.Is(err, repoerrs.ErrNotFound) {
-			return entity.Tender{}, ErrTenderNotFound
-		}
-		logrus.Errorf("TenderService.GetTenderStatus: cannot update tender: %v", err)
-		return entity.Tender{}, ErrCannotUpdateTender
+		logger.FromContext(ctx).Errorf("TenderService.checkRollbackAmounts: cannot get bids: %v", err)
+		return ErrCannotGetBid
 	}
+	return checkBidPrices(restored, bids)
+}
 
-	return tender, nil
+func (s *TenderService) GetTenderVersions(ctx context.Context, tenderId string, userId string) ([]entity.Tender, error) {
+	ctx, span := tracer.Start(ctx, "TenderService.GetTenderVersions")
+	defer span.End()
+
+	tender, err := s.getViewableTender(ctx, tenderId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.repo.Tender.GetTenderVersions(ctx, tenderId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("TenderService.GetTenderVersions: cannot get tender versions: %v", err)
+		return nil, ErrCannotGetTender
+	}
+
+	return append(versions, tender), nil
 }
-func (s *TenderService) EditTender(ctx context.Context, tenderId string, userId string, input entity.EditTenderInput) (entity.Tender, error) {
+func (s *TenderService) GetTenderVersion(ctx context.Context, tenderId string, version int, userId string) (entity.Tender, error) {
+	ctx, span := tracer.Start(ctx, "TenderService.GetTenderVersion")
+	defer span.End()
+
+	tender, err := s.getViewableTender(ctx, tenderId, userId)
+	if err != nil {
+		return entity.Tender{}, err
+	}
+
+	return s.getTenderVersion(ctx, tender, version)
+}
+func (s *TenderService) DiffTenderVersions(ctx context.Context, tenderId string, from int, to int, userId string) (entity.VersionDiff, error) {
+	ctx, span := tracer.Start(ctx, "TenderService.DiffTenderVersions")
+	defer span.End()
+
+	tender, err := s.getViewableTender(ctx, tenderId, userId)
+	if err != nil {
+		return entity.VersionDiff{}, err
+	}
+
+	fromTender, err := s.getTenderVersion(ctx, tender, from)
+	if err != nil {
+		return entity.VersionDiff{}, err
+	}
+	toTender, err := s.getTenderVersion(ctx, tender, to)
+	if err != nil {
+		return entity.VersionDiff{}, err
+	}
+
+	return diffTenders(fromTender, toTender), nil
+}
+func (s *TenderService) CloseExpiredTenders(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "TenderService.CloseExpiredTenders")
+	defer span.End()
+
+	ids, err := s.repo.Tender.CloseExpiredTenders(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("TenderService.CloseExpiredTenders: cannot close tenders: %v", err)
+		return 0, ErrCannotUpdateTender
+	}
+	s.metrics.TendersClosed(len(ids))
+
+	return len(ids), nil
+}
+func (s *TenderService) OpenBids(ctx context.Context, tenderId string, userId string) (entity.Tender, error) {
+	ctx, span := tracer.Start(ctx, "TenderService.OpenBids")
+	defer span.End()
+
 	tender, err := s.repo.Tender.GetTenderById(ctx, tenderId)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
 			return entity.Tender{}, ErrTenderNotFound
 		}
-		logrus.Errorf("TenderService.UpdateTenderStatus: cannot get tender: %v", err)
+		logger.FromContext(ctx).Errorf("TenderService.OpenBids: cannot get tender: %v", err)
 		return entity.Tender{}, ErrCannotGetTender
 	}
-	if tender.Status == "Closed" {
-		return entity.Tender{}, fmt.Errorf("cannot edit closed tender")
+	if !tender.Sealed {
+		return entity.Tender{}, ErrTenderNotSealed
 	}
-
-	userOrganizationId, err := s.repo.Auth.GetUserOrganizationId(ctx, userId)
-	if err != nil {
-		if errors.Is(err, repoerrs.ErrNotFound) {
-			return entity.Tender{}, ErrNotEnoughPermissions
-		}
-		logrus.Errorf("TenderService.UpdateTenderStatus: cannot get user organization id: %v", err)
-		return entity.Tender{}, ErrNotEnoughPermissions
+	if tender.BidsOpenedAt != nil {
+		return entity.Tender{}, ErrBidsAlreadyOpened
 	}
-	if userOrganizationId != tender.OrganizationId {
-		return entity.Tender{}, ErrNotEnoughPermissions
+
+	if err := checkPermission(ctx, s.repo, userId, tender.OrganizationId, entity.PermissionTenderStatus); err != nil {
+		return entity.Tender{}, err
 	}
 
-	tender, err = s.repo.Tender.UpdateTender(ctx, tenderId, input)
+	tender, err = s.repo.Tender.OpenTenderBids(ctx, tenderId)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
-			return entity.Tender{}, ErrTenderNotFound
+			return entity.Tender{}, ErrBidsAlreadyOpened
 		}
-		logrus.Errorf("TenderService.GetTenderStatus: cannot update tender: %v", err)
+		logger.FromContext(ctx).Errorf("TenderService.OpenBids: cannot open tender bids: %v", err)
 		return entity.Tender{}, ErrCannotUpdateTender
 	}
 
 	return tender, nil
 }
-func (s *TenderService) RollbackTender(ctx context.Context, tenderId string, version int, userId string) (entity.Tender, error) {
+
+func (s *TenderService) getViewableTender(ctx context.Context, tenderId string, userId string) (entity.Tender, error) {
 	tender, err := s.repo.Tender.GetTenderById(ctx, tenderId)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
 			return entity.Tender{}, ErrTenderNotFound
 		}
-		logrus.Errorf("TenderService.UpdateTenderStatus: cannot get tender: %v", err)
+		logger.FromContext(ctx).Errorf("TenderService.getViewableTender: cannot get tender: %v", err)
 		return entity.Tender{}, ErrCannotGetTender
 	}
-	if tender.Version <= version {
-		return entity.Tender{}, fmt.Errorf("cannot roll back to the current and higher version")
+
+	if err := checkPermission(ctx, s.repo, userId, tender.OrganizationId, entity.PermissionTenderView); err != nil {
+		return entity.Tender{}, err
+	}
+
+	return tender, nil
+}
+
+func (s *TenderService) getTenderVersion(ctx context.Context, current entity.Tender, version int) (entity.Tender, error) {
+	if version == current.Version {
+		return current, nil
 	}
-	if tender.Status == "Closed" {
-		return entity.Tender{}, fmt.Errorf("cannot edit closed tender")
+	if version > current.Version {
+		return entity.Tender{}, ErrVersionNotFound
 	}
 
-	userOrganizationId, err := s.repo.Auth.GetUserOrganizationId(ctx, userId)
+	tender, err := s.repo.Tender.GetTenderVersion(ctx, current.Id, version)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
-			return entity.Tender{}, ErrNotEnoughPermissions
+			return entity.Tender{}, ErrVersionNotFound
 		}
-		logrus.Errorf("TenderService.UpdateTenderStatus: cannot get user organization id: %v", err)
-		return entity.Tender{}, ErrNotEnoughPermissions
-	}
-	if userOrganizationId != tender.OrganizationId {
-		return entity.Tender{}, ErrNotEnoughPermissions
+		logger.FromContext(ctx).Errorf("TenderService.getTenderVersion: cannot get tender version: %v", err)
+		return entity.Tender{}, ErrCannotGetTender
 	}
 
-	tender, err = s.repo.Tender.RollbackTender(ctx, tenderId, version)
+	return tender, nil
+}
+
+func (s *TenderService) versionConflict(ctx context.Context, repos *repository.Repository, tenderId string) error {
+	tender, err := repos.Tender.GetTenderById(ctx, tenderId)
 	if err != nil {
 		if errors.Is(err, repoerrs.ErrNotFound) {
-			return entity.Tender{}, ErrTenderNotFound
+			return ErrTenderNotFound
 		}
-		logrus.Errorf("TenderService.GetTenderStatus: cannot update tender: %v", err)
-		return entity.Tender{}, ErrCannotUpdateTender
+		logger.FromContext(ctx).Errorf("TenderService.versionConflict: cannot get tender: %v", err)
+		return ErrCannotGetTender
 	}
+	return &VersionConflictError{CurrentVersion: tender.Version}
+}
 
-	return tender, nil
+// checkRestoredDeadlines applies the edit rules to the deadlines a rollback restores: a deadline that changes must be
+// in the future, and the question deadline must not be later than the submission deadline.
+func checkRestoredDeadlines(current entity.Tender, target entity.Tender) error {
+	now := time.Now()
+	if deadlineChanged(current.SubmissionDeadline, target.SubmissionDeadline) && !target.SubmissionDeadline.After(now) {
+		return ErrInvalidDeadline
+	}
+	if deadlineChanged(current.QuestionDeadline, target.QuestionDeadline) && !target.QuestionDeadline.After(now) {
+		return ErrInvalidDeadline
+	}
+	return checkQuestionDeadline(target.QuestionDeadline, target.SubmissionDeadline)
+}
+
+// deadlineChanged reports whether restoring target replaces current with another non-empty deadline.
+func deadlineChanged(current *time.Time, target *time.Time) bool {
+	if target == nil {
+		return false
+	}
+	return current == nil || !current.Equal(*target)
+}
+
+func deadlinePassed(tender entity.Tender) bool {
+	return tender.SubmissionDeadline != nil && !time.Now().Before(*tender.SubmissionDeadline)
+}
+
+func bidsSealed(tender entity.Tender) bool {
+	return tender.Sealed && tender.BidsOpenedAt == nil && tender.Status != "Closed" && !deadlinePassed(tender)
 }
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"errors"
+	"tender-service/internal/entity"
+	"testing"
+	"time"
+)
+
+func TestCheckRestoredDeadlines(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	soon := now.Add(time.Hour)
+	later := now.Add(48 * time.Hour)
+
+	tests := []struct {
+		name    string
+		current entity.Tender
+		target  entity.Tender
+		wantErr error
+	}{
+		{
+			name:    "future deadlines restored",
+			current: entity.Tender{SubmissionDeadline: &soon},
+			target:  entity.Tender{SubmissionDeadline: &later, QuestionDeadline: &soon},
+		},
+		{
+			name:    "deadlines removed",
+			current: entity.Tender{SubmissionDeadline: &soon, QuestionDeadline: &soon},
+			target:  entity.Tender{},
+		},
+		{
+			name:    "unchanged past deadline",
+			current: entity.Tender{SubmissionDeadline: &past},
+			target:  entity.Tender{SubmissionDeadline: &past},
+		},
+		{
+			name:    "past submission deadline restored",
+			current: entity.Tender{SubmissionDeadline: &later},
+			target:  entity.Tender{SubmissionDeadline: &past},
+			wantErr: ErrInvalidDeadline,
+		},
+		{
+			name:    "past question deadline restored",
+			current: entity.Tender{},
+			target:  entity.Tender{QuestionDeadline: &past},
+			wantErr: ErrInvalidDeadline,
+		},
+		{
+			name:    "question deadline after submission deadline",
+			current: entity.Tender{SubmissionDeadline: &later},
+			target:  entity.Tender{SubmissionDeadline: &soon, QuestionDeadline: &later},
+			wantErr: ErrInvalidQuestionDeadline,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkRestoredDeadlines(tt.current, tt.target); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkRestoredDeadlines() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"tender-service/internal/logger"
+	"tender-service/internal/repository"
+)
+
+func inTx(ctx context.Context, repo *repository.Repository, op string, fallback error, fn func(repos *repository.Repository) error) error {
+	var fnErr error
+	err := repo.WithTx(ctx, func(repos *repository.Repository) error {
+		fnErr = fn(repos)
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		logger.FromContext(ctx).Errorf("%s: transaction failed: %v", op, err)
+		return fallback
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"github.com/shopspring/decimal"
+	"tender-service/internal/entity"
+	"time"
+)
+
+func diffTenders(from entity.Tender, to entity.Tender) entity.VersionDiff {
+	return entity.VersionDiff{
+		Id:          from.Id,
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+		Changes: []entity.FieldChange{
+			fieldChange("name", from.Name, to.Name),
+			fieldChange("description", from.Description, to.Description),
+			fieldChange("serviceType", from.ServiceType, to.ServiceType),
+			fieldChange("status", from.Status, to.Status),
+			fieldChange("submissionDeadline", formatTime(from.SubmissionDeadline), formatTime(to.SubmissionDeadline)),
+			fieldChange("questionDeadline", formatTime(from.QuestionDeadline), formatTime(to.QuestionDeadline)),
+			fieldChange("budget", formatAmount(from.Budget), formatAmount(to.Budget)),
+			fieldChange("currency", formatString(from.Currency), formatString(to.Currency)),
+		},
+	}
+}
+
+func diffBids(from entity.Bid, to entity.Bid) entity.VersionDiff {
+	return entity.VersionDiff{
+		Id:          from.Id,
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+		Changes: []entity.FieldChange{
+			fieldChange("name", from.Name, to.Name),
+			fieldChange("description", from.Description, to.Description),
+			fieldChange("status", from.Status, to.Status),
+			fieldChange("price", formatAmount(from.Price), formatAmount(to.Price)),
+			fieldChange("currency", formatString(from.Currency), formatString(to.Currency)),
+		},
+	}
+}
+
+func fieldChange(field string, from string, to string) entity.FieldChange {
+	return entity.FieldChange{
+		Field:   field,
+		From:    from,
+		To:      to,
+		Changed: from != to,
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatAmount(amount *decimal.Decimal) string {
+	if amount == nil {
+		return ""
+	}
+	return amount.StringFixed(2)
+}
+
+func formatString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"tender-service/internal/entity"
+	"tender-service/internal/logger"
+	"tender-service/internal/repository"
+	"tender-service/internal/repository/repoerrs"
+	"tender-service/internal/webhook"
+	"time"
+)
+
+type WebhookService struct {
+	repo        *repository.Repository
+	sender      webhook.Sender
+	timeout     time.Duration
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	batchSize   int
+}
+
+func NewWebhookService(repo *repository.Repository, sender webhook.Sender, timeout time.Duration, maxAttempts int, baseBackoff time.Duration, maxBackoff time.Duration, batchSize int) *WebhookService {
+	return &WebhookService{
+		repo:        repo,
+		sender:      sender,
+		timeout:     timeout,
+		maxAttempts: maxAttempts,
+		baseBackoff: baseBackoff,
+		maxBackoff:  maxBackoff,
+		batchSize:   batchSize,
+	}
+}
+
+func (s *WebhookService) CreateWebhook(ctx context.Context, organizationId string, userId string, input entity.CreateWebhookInput) (entity.CreatedWebhook, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.CreateWebhook")
+	defer span.End()
+
+	if err := checkPermission(ctx, s.repo, userId, organizationId, entity.PermissionOrganizationManage); err != nil {
+		return entity.CreatedWebhook{}, err
+	}
+	if err := s.sender.ValidateURL(input.Url); err != nil {
+		return entity.CreatedWebhook{}, ErrWebhookUrlNotAllowed.WithDetails(map[string]any{"reason": err.Error()})
+	}
+
+	secret, err := randomHex(32)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("WebhookService.CreateWebhook: cannot generate secret: %v", err)
+		return entity.CreatedWebhook{}, ErrCannotCreateWebhook
+	}
+
+	webhook, err := s.repo.Webhook.CreateWebhook(ctx, entity.Webhook{
+		OrganizationId: organizationId,
+		Url:            input.Url,
+		Secret:         secret,
+		Events:         uniqueStrings(input.Events),
+		CreatedBy:      &userId,
+	})
+	if err != nil {
+		logger.FromContext(ctx).Errorf("WebhookService.CreateWebhook: cannot create webhook: %v", err)
+		return entity.CreatedWebhook{}, ErrCannotCreateWebhook
+	}
+
+	return entity.CreatedWebhook{Webhook: webhook, Secret: secret}, nil
+}
+func (s *WebhookService) GetWebhooks(ctx context.Context, organizationId string, userId string) ([]entity.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.GetWebhooks")
+	defer span.End()
+
+	if err := checkPermission(ctx, s.repo, userId, organizationId, entity.PermissionOrganizationManage); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.repo.Webhook.GetWebhooks(ctx, organizationId)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("WebhookService.GetWebhooks: cannot get webhooks: %v", err)
+		return nil, ErrCannotGetWebhook
+	}
+
+	return webhooks, nil
+}
+func (s *WebhookService) DeleteWebhook(ctx context.Context, organizationId string, webhookId string, userId string) error {
+	ctx, span := tracer.Start(ctx, "WebhookService.DeleteWebhook")
+	defer span.End()
+
+	if _, err := s.getWebhook(ctx, organizationId, webhookId, userId); err != nil {
+		return err
+	}
+
+	err := s.repo.Webhook.DeleteWebhook(ctx, webhookId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return ErrWebhookNotFound
+		}
+		logger.FromContext(ctx).Errorf("WebhookService.DeleteWebhook: cannot delete webhook: %v", err)
+		return ErrCannotDeleteWebhook
+	}
+
+	return nil
+}
+func (s *WebhookService) GetDeliveries(ctx context.Context, organizationId string, webhookId string, userId string, limit int, offset int) ([]entity.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.GetDeliveries")
+	defer span.End()
+
+	if _, err := s.getWebhook(ctx, organizationId, webhookId, userId); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.repo.Webhook.GetDeliveries(ctx, webhookId, limit, offset)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("WebhookService.GetDeliveries: cannot get deliveries: %v", err)
+		return nil, ErrCannotGetWebhook
+	}
+
+	return deliveries, nil
+}
+func (s *WebhookService) DeliverPending(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "WebhookService.DeliverPending")
+	defer span.End()
+
+	deliveries, err := s.repo.Webhook.ClaimDueDeliveries(ctx, s.batchSize, 2*s.timeout)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("WebhookService.DeliverPending: cannot claim deliveries: %v", err)
+		return 0, ErrCannotDeliverWebhook
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery entity.PendingDelivery) {
+			defer wg.Done()
+			s.deliver(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+func (s *WebhookService) deliver(ctx context.Context, pending entity.PendingDelivery) {
+	sendCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	statusCode, err := s.sender.Send(sendCtx, webhook.Request{
+		Url:        pending.Url,
+		Secret:     pending.Secret,
+		DeliveryId: pending.Id,
+		Event:      pending.Event,
+		Payload:    pending.Payload,
+	})
+	cancel()
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+
+	delivery := pending.WebhookDelivery
+	delivery.Attempts++
+	delivery.LastStatusCode = nil
+	if statusCode != 0 {
+		delivery.LastStatusCode = &statusCode
+	}
+	delivery.LastError = nil
+
+	now := time.Now()
+	switch {
+	case err == nil:
+		delivery.Status = "Delivered"
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= s.maxAttempts:
+		delivery.Status = "Failed"
+		delivery.LastError = truncatedError(err)
+	default:
+		delivery.NextAttemptAt = now.Add(s.backoff(delivery.Attempts))
+		delivery.LastError = truncatedError(err)
+	}
+
+	if err := s.repo.Webhook.UpdateDelivery(ctx, delivery); err != nil {
+		logger.FromContext(ctx).Errorf("WebhookService.deliver: cannot update delivery %s: %v", delivery.Id, err)
+	}
+}
+
+func (s *WebhookService) backoff(attempts int) time.Duration {
+	backoff := s.baseBackoff
+	for i := 1; i < attempts && backoff < s.maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, s.maxBackoff)
+}
+
+func (s *WebhookService) getWebhook(ctx context.Context, organizationId string, webhookId string, userId string) (entity.Webhook, error) {
+	if err := checkPermission(ctx, s.repo, userId, organizationId, entity.PermissionOrganizationManage); err != nil {
+		return entity.Webhook{}, err
+	}
+
+	webhook, err := s.repo.Webhook.GetWebhookById(ctx, webhookId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return entity.Webhook{}, ErrWebhookNotFound
+		}
+		logger.FromContext(ctx).Errorf("WebhookService.getWebhook: cannot get webhook: %v", err)
+		return entity.Webhook{}, ErrCannotGetWebhook
+	}
+	if webhook.OrganizationId != organizationId {
+		return entity.Webhook{}, ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+func (s *WebhookService) Name() string {
+	return "webhook"
+}
+
+func (s *WebhookService) Handle(ctx context.Context, event entity.Event) error {
+	ctx, span := tracer.Start(ctx, "WebhookService.Handle")
+	defer span.End()
+
+	organizationIds, err := s.eventOrganizations(ctx, event)
+	if err != nil {
+		return err
+	}
+	if len(organizationIds) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("WebhookService.Handle - json.Marshal: %v", err)
+	}
+
+	return s.repo.Webhook.EnqueueDeliveries(ctx, event, organizationIds, payload)
+}
+
+func (s *WebhookService) eventOrganizations(ctx context.Context, event entity.Event) ([]string, error) {
+	tender, err := s.repo.Tender.GetTenderById(ctx, event.Data.TenderId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("WebhookService.eventOrganizations - GetTenderById: %v", err)
+	}
+	if event.Data.BidId == "" {
+		return []string{tender.OrganizationId}, nil
+	}
+
+	var organizationIds []string
+	if event.Data.Status != "Created" {
+		organizationIds = append(organizationIds, tender.OrganizationId)
+	}
+
+	bid, err := s.repo.Bid.GetBidById(ctx, event.Data.BidId)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return organizationIds, nil
+		}
+		return nil, fmt.Errorf("WebhookService.eventOrganizations - GetBidById: %v", err)
+	}
+	if bid.AuthorType == "Organization" {
+		members, err := userMemberships(ctx, s.repo, bid.AuthorId)
+		if err != nil && !errors.Is(err, repoerrs.ErrNotFound) {
+			return nil, fmt.Errorf("WebhookService.eventOrganizations - userMemberships: %v", err)
+		}
+		for _, member := range members {
+			organizationIds = append(organizationIds, member.OrganizationId)
+		}
+	}
+
+	return uniqueStrings(organizationIds), nil
+}
+
+func randomHex(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+func truncatedError(err error) *string {
+	message := err.Error()
+	if len(message) > 1000 {
+		message = message[:1000]
+	}
+	return &message
+}
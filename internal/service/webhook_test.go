@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := []struct {
+		name        string
+		baseBackoff time.Duration
+		maxBackoff  time.Duration
+		attempts    int
+		want        time.Duration
+	}{
+		{name: "first retry", baseBackoff: 30 * time.Second, maxBackoff: 6 * time.Hour, attempts: 1, want: 30 * time.Second},
+		{name: "doubles per attempt", baseBackoff: 30 * time.Second, maxBackoff: 6 * time.Hour, attempts: 2, want: time.Minute},
+		{name: "fifth retry", baseBackoff: 30 * time.Second, maxBackoff: 6 * time.Hour, attempts: 5, want: 8 * time.Minute},
+		{name: "capped at max", baseBackoff: 30 * time.Second, maxBackoff: 6 * time.Hour, attempts: 11, want: 6 * time.Hour},
+		{name: "stays capped without overflow", baseBackoff: 30 * time.Second, maxBackoff: 6 * time.Hour, attempts: 1000, want: 6 * time.Hour},
+		{name: "base above max", baseBackoff: time.Hour, maxBackoff: time.Minute, attempts: 1, want: time.Minute},
+		{name: "no attempts yet", baseBackoff: 30 * time.Second, maxBackoff: 6 * time.Hour, attempts: 0, want: 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &WebhookService{baseBackoff: tt.baseBackoff, maxBackoff: tt.maxBackoff}
+			if got := s.backoff(tt.attempts); got != tt.want {
+				t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
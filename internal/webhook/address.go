@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+var ErrForbiddenAddress = errors.New("webhook address is not allowed")
+
+// Deliveries are made from inside the cluster, so receivers must not be able to reach loopback, private networks
+// or the cloud metadata endpoint (169.254.169.254).
+var forbiddenPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("127.0.0.0/8"),
+	netip.MustParsePrefix("169.254.0.0/16"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("224.0.0.0/4"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("::/128"),
+	netip.MustParsePrefix("::1/128"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("fc00::/7"),
+	netip.MustParsePrefix("fe80::/10"),
+	netip.MustParsePrefix("ff00::/8"),
+}
+
+// ValidateURL checks a webhook URL before it is stored. Host names are checked again after resolution by
+// the dialer, which also covers DNS records pointing at internal addresses.
+func ValidateURL(rawURL string, allowInsecure bool) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrForbiddenAddress, err)
+	}
+	if u.Scheme != "https" && !(allowInsecure && u.Scheme == "http") {
+		return fmt.Errorf("%w: scheme must be https", ErrForbiddenAddress)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: credentials in url", ErrForbiddenAddress)
+	}
+
+	host := u.Hostname()
+	if host == "" {
+		return fmt.Errorf("%w: empty host", ErrForbiddenAddress)
+	}
+	if allowInsecure {
+		return nil
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return checkAddr(ip)
+	}
+
+	return nil
+}
+
+func checkAddr(ip netip.Addr) error {
+	ip = ip.Unmap()
+	for _, prefix := range forbiddenPrefixes {
+		if prefix.Contains(ip) {
+			return fmt.Errorf("%w: %s", ErrForbiddenAddress, ip)
+		}
+	}
+	return nil
+}
+
+// dialControl runs after DNS resolution and before connecting, so it sees the address actually dialed.
+func dialControl(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrForbiddenAddress, err)
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrForbiddenAddress, err)
+	}
+	return checkAddr(ip)
+}
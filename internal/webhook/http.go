@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type HTTPSender struct {
+	client        *http.Client
+	userAgent     string
+	allowInsecure bool
+}
+
+// NewHTTPSender creates a sender that only talks https to public addresses. allowInsecure lifts both
+// restrictions and is meant for local development only.
+func NewHTTPSender(userAgent string, allowInsecure bool) *HTTPSender {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if !allowInsecure {
+		dialer.Control = dialControl
+	}
+
+	return &HTTPSender{
+		client: &http.Client{
+			Transport: &http.Transport{
+				Proxy:               nil,
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: 10 * time.Second,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		userAgent:     userAgent,
+		allowInsecure: allowInsecure,
+	}
+}
+
+func (s *HTTPSender) ValidateURL(rawURL string) error {
+	return ValidateURL(rawURL, s.allowInsecure)
+}
+
+func (s *HTTPSender) Send(ctx context.Context, request Request) (int, error) {
+	if err := s.ValidateURL(request.Url); err != nil {
+		return 0, fmt.Errorf("HTTPSender.Send - s.ValidateURL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, request.Url, bytes.NewReader(request.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("HTTPSender.Send - http.NewRequestWithContext: %v", err)
+	}
+
+	timestamp := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", s.userAgent)
+	req.Header.Set(HeaderEvent, request.Event)
+	req.Header.Set(HeaderDelivery, request.DeliveryId)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(request.Secret, timestamp, request.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("HTTPSender.Send - s.client.Do: %v", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
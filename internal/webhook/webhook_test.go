@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	payload := []byte(`{"event":"tender.published"}`)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp time.Time
+		payload   []byte
+		want      string
+	}{
+		{
+			name:      "known vector",
+			secret:    "whsec_test",
+			timestamp: time.Unix(1700000000, 0),
+			payload:   payload,
+			want:      "sha256=e370467430be3f53e8a966a711bdd2a650db0094ca99abd2cf79a77124696c29",
+		},
+		{
+			name:      "timestamp is part of the signature",
+			secret:    "whsec_test",
+			timestamp: time.Unix(1700000001, 0),
+			payload:   payload,
+			want:      "sha256=b1fc28058f432e116ba8d74d7f969ce0409fd44c076d9dd2b0c6889694ba99c3",
+		},
+		{
+			name:      "sub-second precision is dropped",
+			secret:    "whsec_test",
+			timestamp: time.Unix(1700000000, 999999999),
+			payload:   payload,
+			want:      "sha256=e370467430be3f53e8a966a711bdd2a650db0094ca99abd2cf79a77124696c29",
+		},
+		{
+			name:      "empty secret and payload",
+			secret:    "",
+			timestamp: time.Unix(0, 0),
+			payload:   nil,
+			want:      "sha256=b849d5a581847b281957065739df36df2463d1977ea8d6e1e4e6cf33fadc68c3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.timestamp, tt.payload); got != tt.want {
+				t.Errorf("Sign() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
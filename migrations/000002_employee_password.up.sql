@@ -0,0 +1,2 @@
+ALTER TABLE employee
+    ADD COLUMN IF NOT EXISTS password_hash VARCHAR(255);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS organization_responsible_organization_user_idx;
+
+ALTER TABLE organization_responsible
+    DROP COLUMN IF EXISTS role;
+
+DROP TYPE IF EXISTS organization_role;
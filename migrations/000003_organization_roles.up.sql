@@ -0,0 +1,17 @@
+CREATE TYPE organization_role AS ENUM ('Owner', 'Editor', 'Approver', 'Viewer');
+
+ALTER TABLE organization_responsible
+    ADD COLUMN role organization_role NOT NULL DEFAULT 'Viewer';
+
+-- Existing responsibles start with the least privilege; only the earliest registered responsible of each
+-- organization becomes its Owner and can grant the remaining roles.
+UPDATE organization_responsible r
+SET role = 'Owner'
+FROM (SELECT DISTINCT ON (r.organization_id) r.id
+      FROM organization_responsible r
+               JOIN employee e ON e.id = r.user_id
+      ORDER BY r.organization_id, e.created_at, r.id) owners
+WHERE r.id = owners.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS organization_responsible_organization_user_idx
+    ON organization_responsible (organization_id, user_id);
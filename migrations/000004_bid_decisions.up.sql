@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS bid_decisions
+(
+    id         UUID PRIMARY KEY      DEFAULT gen_random_uuid(),
+    bid_id     UUID         NOT NULL REFERENCES bids (id) ON DELETE CASCADE,
+    user_id    UUID         NOT NULL REFERENCES employee (id) ON DELETE CASCADE,
+    decision   bid_decision NOT NULL,
+    created_at TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (bid_id, user_id)
+);
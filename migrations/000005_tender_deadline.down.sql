@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS tenders_submission_deadline_idx;
+
+ALTER TABLE tenders_old_version
+    DROP COLUMN IF EXISTS submission_deadline;
+
+ALTER TABLE tenders
+    DROP COLUMN IF EXISTS submission_deadline;
@@ -0,0 +1,9 @@
+ALTER TABLE tenders
+    ADD COLUMN submission_deadline TIMESTAMPTZ;
+
+ALTER TABLE tenders_old_version
+    ADD COLUMN submission_deadline TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS tenders_submission_deadline_idx
+    ON tenders (submission_deadline)
+    WHERE status <> 'Closed';
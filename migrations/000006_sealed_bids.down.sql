@@ -0,0 +1,6 @@
+ALTER TABLE tenders_old_version
+    DROP COLUMN IF EXISTS sealed;
+
+ALTER TABLE tenders
+    DROP COLUMN IF EXISTS bids_opened_at,
+    DROP COLUMN IF EXISTS sealed;
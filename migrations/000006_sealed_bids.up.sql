@@ -0,0 +1,6 @@
+ALTER TABLE tenders
+    ADD COLUMN sealed         BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN bids_opened_at TIMESTAMPTZ;
+
+ALTER TABLE tenders_old_version
+    ADD COLUMN sealed BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,19 @@
+DROP INDEX IF EXISTS bids_tender_id_price_idx;
+
+ALTER TABLE bids_old_version
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS price;
+
+ALTER TABLE bids
+    DROP CONSTRAINT IF EXISTS bids_price_currency_check,
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS price;
+
+ALTER TABLE tenders_old_version
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS budget;
+
+ALTER TABLE tenders
+    DROP CONSTRAINT IF EXISTS tenders_budget_currency_check,
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS budget;
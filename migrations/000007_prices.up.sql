@@ -0,0 +1,19 @@
+ALTER TABLE tenders
+    ADD COLUMN budget   NUMERIC(18, 2) CHECK (budget > 0),
+    ADD COLUMN currency CHAR(3),
+    ADD CONSTRAINT tenders_budget_currency_check CHECK ((budget IS NULL) = (currency IS NULL));
+
+ALTER TABLE tenders_old_version
+    ADD COLUMN budget   NUMERIC(18, 2),
+    ADD COLUMN currency CHAR(3);
+
+ALTER TABLE bids
+    ADD COLUMN price    NUMERIC(18, 2) CHECK (price > 0),
+    ADD COLUMN currency CHAR(3),
+    ADD CONSTRAINT bids_price_currency_check CHECK ((price IS NULL) = (currency IS NULL));
+
+ALTER TABLE bids_old_version
+    ADD COLUMN price    NUMERIC(18, 2),
+    ADD COLUMN currency CHAR(3);
+
+CREATE INDEX IF NOT EXISTS bids_tender_id_price_idx ON bids (tender_id, price);
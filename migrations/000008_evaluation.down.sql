@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bid_scores_old_version;
+DROP TABLE IF EXISTS bid_scores;
+DROP TABLE IF EXISTS tender_criteria;
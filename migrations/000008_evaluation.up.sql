@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS tender_criteria
+(
+    id          UUID PRIMARY KEY      DEFAULT gen_random_uuid(),
+    tender_id   UUID         NOT NULL REFERENCES tenders (id) ON DELETE CASCADE,
+    name        VARCHAR(100) NOT NULL,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    weight      INT          NOT NULL CHECK (weight > 0),
+    max_score   INT          NOT NULL DEFAULT 10 CHECK (max_score > 0),
+    created_at  TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tender_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS bid_scores
+(
+    id           UUID PRIMARY KEY      DEFAULT gen_random_uuid(),
+    bid_id       UUID         NOT NULL REFERENCES bids (id) ON DELETE CASCADE,
+    criterion_id UUID         NOT NULL REFERENCES tender_criteria (id) ON DELETE CASCADE,
+    user_id      UUID         NOT NULL REFERENCES employee (id) ON DELETE CASCADE,
+    score        INT          NOT NULL CHECK (score >= 0),
+    comment      VARCHAR(500) NOT NULL DEFAULT '',
+    bid_version  INT          NOT NULL,
+    version      INT          NOT NULL DEFAULT 1,
+    created_at   TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (bid_id, criterion_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS bid_scores_old_version
+(
+    id           UUID PRIMARY KEY      DEFAULT gen_random_uuid(),
+    score_id     UUID         NOT NULL REFERENCES bid_scores (id) ON DELETE CASCADE,
+    bid_id       UUID         NOT NULL,
+    criterion_id UUID         NOT NULL,
+    user_id      UUID         NOT NULL,
+    score        INT          NOT NULL,
+    comment      VARCHAR(500) NOT NULL DEFAULT '',
+    bid_version  INT          NOT NULL,
+    version      INT          NOT NULL,
+    created_at   TIMESTAMP             DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS bid_scores_old_version_bid_id_idx ON bid_scores_old_version (bid_id);
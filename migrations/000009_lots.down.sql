@@ -0,0 +1,16 @@
+DELETE
+FROM bid_decisions d
+WHERE EXISTS (SELECT 1
+              FROM bid_decisions o
+              WHERE o.bid_id = d.bid_id
+                AND o.user_id = d.user_id
+                AND o.created_at < d.created_at);
+
+ALTER TABLE bid_decisions
+    DROP CONSTRAINT IF EXISTS bid_decisions_bid_id_lot_id_user_id_key,
+    DROP COLUMN IF EXISTS lot_id,
+    ADD CONSTRAINT bid_decisions_bid_id_user_id_key UNIQUE (bid_id, user_id);
+
+DROP TABLE IF EXISTS bid_lots;
+DROP TABLE IF EXISTS tender_lots;
+DROP TYPE IF EXISTS lot_status;
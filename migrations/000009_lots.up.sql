@@ -0,0 +1,68 @@
+CREATE TYPE lot_status AS ENUM ('Open', 'Awarded', 'Cancelled');
+
+CREATE TABLE IF NOT EXISTS tender_lots
+(
+    id             UUID PRIMARY KEY             DEFAULT gen_random_uuid(),
+    tender_id      UUID                NOT NULL REFERENCES tenders (id) ON DELETE CASCADE,
+    number         INT                 NOT NULL CHECK (number > 0),
+    name           VARCHAR(100)        NOT NULL,
+    description    VARCHAR(500)        NOT NULL,
+    service_type   tender_service_type NOT NULL,
+    budget         NUMERIC(18, 2) CHECK (budget > 0),
+    status         lot_status          NOT NULL DEFAULT 'Open',
+    awarded_bid_id UUID REFERENCES bids (id) ON DELETE SET NULL,
+    created_at     TIMESTAMP                    DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP                    DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tender_id, number)
+);
+
+CREATE TABLE IF NOT EXISTS bid_lots
+(
+    bid_id   UUID NOT NULL REFERENCES bids (id) ON DELETE CASCADE,
+    lot_id   UUID NOT NULL REFERENCES tender_lots (id) ON DELETE CASCADE,
+    price    NUMERIC(18, 2) CHECK (price > 0),
+    decision bid_decision,
+    PRIMARY KEY (bid_id, lot_id)
+);
+
+CREATE INDEX IF NOT EXISTS bid_lots_lot_id_idx ON bid_lots (lot_id);
+
+INSERT INTO tender_lots (tender_id, number, name, description, service_type, budget, status, awarded_bid_id)
+SELECT t.id,
+       1,
+       t.name,
+       t.description,
+       t.service_type,
+       t.budget,
+       CASE
+           WHEN b.id IS NOT NULL THEN 'Awarded'::lot_status
+           WHEN t.status = 'Closed' THEN 'Cancelled'::lot_status
+           ELSE 'Open'::lot_status
+           END,
+       b.id
+FROM tenders t
+         LEFT JOIN LATERAL (SELECT id
+                            FROM bids
+                            WHERE tender_id = t.id
+                              AND decision = 'Approved'
+                            ORDER BY updated_at
+                            LIMIT 1) b ON TRUE;
+
+INSERT INTO bid_lots (bid_id, lot_id, price, decision)
+SELECT b.id, l.id, b.price, b.decision
+FROM bids b
+         JOIN tender_lots l ON l.tender_id = b.tender_id AND l.number = 1;
+
+ALTER TABLE bid_decisions
+    ADD COLUMN lot_id UUID REFERENCES tender_lots (id) ON DELETE CASCADE;
+
+UPDATE bid_decisions d
+SET lot_id = l.id
+FROM bids b
+         JOIN tender_lots l ON l.tender_id = b.tender_id AND l.number = 1
+WHERE b.id = d.bid_id;
+
+ALTER TABLE bid_decisions
+    ALTER COLUMN lot_id SET NOT NULL,
+    DROP CONSTRAINT IF EXISTS bid_decisions_bid_id_user_id_key,
+    ADD CONSTRAINT bid_decisions_bid_id_lot_id_user_id_key UNIQUE (bid_id, lot_id, user_id);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS attachments
+(
+    id                 UUID PRIMARY KEY      DEFAULT gen_random_uuid(),
+    tender_id          UUID REFERENCES tenders (id) ON DELETE CASCADE,
+    bid_id             UUID REFERENCES bids (id) ON DELETE CASCADE,
+    file_name          VARCHAR(255) NOT NULL,
+    content_type       VARCHAR(100) NOT NULL,
+    size               BIGINT       NOT NULL CHECK (size >= 0),
+    checksum           CHAR(64)     NOT NULL,
+    storage_key        VARCHAR(300) NOT NULL,
+    uploaded_by        UUID REFERENCES employee (id) ON DELETE SET NULL,
+    added_in_version   INT          NOT NULL,
+    removed_in_version INT,
+    created_at         TIMESTAMP             DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((tender_id IS NULL) <> (bid_id IS NULL)),
+    CHECK (removed_in_version IS NULL OR removed_in_version > added_in_version)
+);
+
+CREATE INDEX IF NOT EXISTS attachments_tender_id_idx ON attachments (tender_id) WHERE tender_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS attachments_bid_id_idx ON attachments (bid_id) WHERE bid_id IS NOT NULL;
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS tender_questions;
+
+DROP TYPE IF EXISTS question_visibility;
+
+ALTER TABLE tenders_old_version
+    DROP COLUMN IF EXISTS question_deadline;
+
+ALTER TABLE tenders
+    DROP COLUMN IF EXISTS question_deadline;
@@ -0,0 +1,23 @@
+ALTER TABLE tenders
+    ADD COLUMN question_deadline TIMESTAMPTZ;
+
+ALTER TABLE tenders_old_version
+    ADD COLUMN question_deadline TIMESTAMPTZ;
+
+CREATE TYPE question_visibility AS ENUM ('Public', 'Private');
+
+CREATE TABLE IF NOT EXISTS tender_questions
+(
+    id          UUID PRIMARY KEY       DEFAULT gen_random_uuid(),
+    tender_id   UUID          NOT NULL REFERENCES tenders (id) ON DELETE CASCADE,
+    author_id   UUID          NOT NULL REFERENCES employee (id) ON DELETE CASCADE,
+    text        VARCHAR(1000) NOT NULL,
+    answer      VARCHAR(2000),
+    visibility  question_visibility,
+    answered_by UUID REFERENCES employee (id) ON DELETE SET NULL,
+    answered_at TIMESTAMP,
+    created_at  TIMESTAMP              DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((answer IS NULL) = (visibility IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS tender_questions_tender_id_idx ON tender_questions (tender_id, created_at);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS tenders_published_created_at_idx;
+
+DROP INDEX IF EXISTS tenders_organization_id_idx;
+
+DROP INDEX IF EXISTS tenders_search_vector_idx;
+
+ALTER TABLE tenders
+    DROP COLUMN IF EXISTS search_vector;
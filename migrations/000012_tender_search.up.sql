@@ -0,0 +1,15 @@
+ALTER TABLE tenders
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', name), 'A') ||
+        setweight(to_tsvector('english', name), 'A') ||
+        setweight(to_tsvector('russian', description), 'B') ||
+        setweight(to_tsvector('english', description), 'B')
+        ) STORED;
+
+CREATE INDEX IF NOT EXISTS tenders_search_vector_idx ON tenders USING GIN (search_vector);
+
+CREATE INDEX IF NOT EXISTS tenders_organization_id_idx ON tenders (organization_id);
+
+CREATE INDEX IF NOT EXISTS tenders_published_created_at_idx
+    ON tenders (created_at)
+    WHERE status = 'Published';
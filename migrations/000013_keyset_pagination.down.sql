@@ -0,0 +1,19 @@
+CREATE INDEX IF NOT EXISTS tenders_published_created_at_idx
+    ON tenders (created_at)
+    WHERE status = 'Published';
+
+DROP INDEX IF EXISTS bids_tender_id_price_id_idx;
+
+DROP INDEX IF EXISTS bids_tender_id_created_at_id_idx;
+
+DROP INDEX IF EXISTS bids_tender_id_name_id_idx;
+
+DROP INDEX IF EXISTS bids_author_id_name_id_idx;
+
+DROP INDEX IF EXISTS tenders_creator_id_name_id_idx;
+
+DROP INDEX IF EXISTS tenders_published_deadline_id_idx;
+
+DROP INDEX IF EXISTS tenders_published_created_at_id_idx;
+
+DROP INDEX IF EXISTS tenders_published_name_id_idx;
@@ -0,0 +1,24 @@
+CREATE INDEX IF NOT EXISTS tenders_published_name_id_idx
+    ON tenders (name, id)
+    WHERE status = 'Published';
+
+CREATE INDEX IF NOT EXISTS tenders_published_created_at_id_idx
+    ON tenders (created_at, id)
+    WHERE status = 'Published';
+
+CREATE INDEX IF NOT EXISTS tenders_published_deadline_id_idx
+    ON tenders ((COALESCE(submission_deadline, 'infinity')), id)
+    WHERE status = 'Published';
+
+CREATE INDEX IF NOT EXISTS tenders_creator_id_name_id_idx ON tenders (creator_id, name, id);
+
+CREATE INDEX IF NOT EXISTS bids_author_id_name_id_idx ON bids (author_id, name, id);
+
+CREATE INDEX IF NOT EXISTS bids_tender_id_name_id_idx ON bids (tender_id, name, id);
+
+CREATE INDEX IF NOT EXISTS bids_tender_id_created_at_id_idx ON bids (tender_id, created_at, id);
+
+CREATE INDEX IF NOT EXISTS bids_tender_id_price_id_idx
+    ON bids (tender_id, (COALESCE(price, 10000000000000000)), id);
+
+DROP INDEX IF EXISTS tenders_published_created_at_idx;
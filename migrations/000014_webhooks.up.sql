@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS webhooks
+(
+    id              UUID PRIMARY KEY       DEFAULT gen_random_uuid(),
+    organization_id UUID          NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    url             VARCHAR(2048) NOT NULL,
+    secret          VARCHAR(128)  NOT NULL,
+    events          TEXT[]        NOT NULL,
+    created_by      UUID REFERENCES employee (id) ON DELETE SET NULL,
+    created_at      TIMESTAMP              DEFAULT CURRENT_TIMESTAMP,
+    CHECK (cardinality(events) > 0)
+);
+
+CREATE INDEX IF NOT EXISTS webhooks_organization_id_idx ON webhooks (organization_id);
+
+CREATE TYPE webhook_delivery_status AS ENUM ('Pending', 'Delivered', 'Failed');
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries
+(
+    id               UUID PRIMARY KEY                 DEFAULT gen_random_uuid(),
+    webhook_id       UUID                    NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id         UUID                    NOT NULL,
+    event            VARCHAR(50)             NOT NULL,
+    payload          JSONB                   NOT NULL,
+    status           webhook_delivery_status NOT NULL DEFAULT 'Pending',
+    attempts         INT                     NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMP               NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_status_code INT,
+    last_error       VARCHAR(1000),
+    delivered_at     TIMESTAMP,
+    created_at       TIMESTAMP                        DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'Pending';
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS outbox
+(
+    id           BIGSERIAL PRIMARY KEY,
+    event_id     UUID        NOT NULL DEFAULT gen_random_uuid(),
+    event_type   VARCHAR(50) NOT NULL,
+    payload      JSONB       NOT NULL,
+    created_at   TIMESTAMP            DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS outbox_unpublished_idx ON outbox (id) WHERE published_at IS NULL;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS password_reset
+(
+    token_hash  VARCHAR(64) PRIMARY KEY,
+    employee_id UUID      NOT NULL REFERENCES employee (id) ON DELETE CASCADE,
+    created_by  UUID      REFERENCES employee (id) ON DELETE SET NULL,
+    expires_at  TIMESTAMP NOT NULL,
+    used_at     TIMESTAMP,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS password_reset_employee_idx ON password_reset (employee_id);